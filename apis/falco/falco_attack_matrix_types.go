@@ -0,0 +1,86 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falco
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FalcoAttackMatrix is a read-only view of the stored FalcoEvents by MITRE ATT&CK tactic and technique.
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=get,list
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoAttackMatrix struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Spec   FalcoAttackMatrixSpec
+	Status FalcoAttackMatrixStatus
+}
+
+type FalcoAttackMatrixSpec struct {
+	Window metav1.Duration
+}
+
+type FalcoAttackMatrixStatus struct {
+	Since          metav1.Time
+	Until          metav1.Time
+	Events         int64
+	UnmappedEvents int64
+	Tactics        []AttackTactic
+}
+
+type AttackTactic struct {
+	ID          string
+	Name        string
+	Events      int64
+	Occurrences int64
+	Techniques  []AttackTechnique
+}
+
+type AttackTechnique struct {
+	ID           string
+	Name         string
+	Events       int64
+	Occurrences  int64
+	Rules        []string
+	LatestEvents []AttackEventReference
+	Workloads    []AttackWorkload
+}
+
+type AttackEventReference struct {
+	Name string
+	UUID string
+	Rule string
+	Time metav1.MicroTime
+}
+
+type AttackWorkload struct {
+	Namespace string
+	Name      string
+	Node      string
+	Events    int64
+}
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoAttackMatrixList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+	Items []FalcoAttackMatrix
+}
@@ -21,6 +21,13 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// Labels of a FalcoEvent naming the namespace, pod and node it was reported for.
+const (
+	LabelNamespaceName = "k8s.ns.name"
+	LabelPodName       = "k8s.pod.name"
+	LabelNodeName      = "k8s.node.name"
+)
+
 // FalcoEvent defines the vulnerability report a Docker image reference.
 
 // +genclient
@@ -29,20 +36,94 @@ import (
 type FalcoEvent struct {
 	metav1.TypeMeta
 	metav1.ObjectMeta
-	Spec FalcoEventSpec
+	Spec   FalcoEventSpec
+	Status FalcoEventStatus
 }
 
+type Priority string
+
+const (
+	PriorityEmergency     Priority = "Emergency"
+	PriorityAlert         Priority = "Alert"
+	PriorityCritical      Priority = "Critical"
+	PriorityError         Priority = "Error"
+	PriorityWarning       Priority = "Warning"
+	PriorityNotice        Priority = "Notice"
+	PriorityInformational Priority = "Informational"
+	PriorityDebug         Priority = "Debug"
+)
+
 type FalcoEventSpec struct {
 	UUID         string
 	Output       string
-	Priority     string
+	Priority     Priority
 	Rule         string
-	Time         metav1.Time
+	Time         metav1.MicroTime
 	OutputFields apiextensionsv1.JSON
 	Source       string
 	Tags         []string
 	Hostname     string
 	Nodename     string
+
+	Workload  *WorkloadInfo
+	Container *ContainerInfo
+	Process   *ProcessInfo
+	Network   *NetworkInfo
+}
+
+type WorkloadInfo struct {
+	Namespace string
+	Pod       string
+	PodUID    string
+}
+
+type ContainerInfo struct {
+	ID              string
+	Name            string
+	ImageRepository string
+	ImageTag        string
+	ImageDigest     string
+	Privileged      *bool
+}
+
+type ProcessInfo struct {
+	Name       string
+	Executable string
+	Cmdline    string
+	PID        *int64
+	ParentName string
+	ParentPID  *int64
+	Cwd        string
+	User       string
+	UID        *int64
+	LoginUser  string
+	TTY        *int64
+}
+
+type NetworkInfo struct {
+	FDName     string
+	FDType     string
+	L4Protocol string
+	ClientIP   string
+	ClientPort *int64
+	ServerIP   string
+	ServerPort *int64
+}
+
+type TriageState string
+
+const (
+	TriageStateOpen          TriageState = "Open"
+	TriageStateAcknowledged  TriageState = "Acknowledged"
+	TriageStateResolved      TriageState = "Resolved"
+	TriageStateFalsePositive TriageState = "FalsePositive"
+)
+
+type FalcoEventStatus struct {
+	Count       int64
+	FirstSeen   *metav1.MicroTime
+	LastSeen    *metav1.MicroTime
+	TriageState TriageState
 }
 
 // +genclient:nonNamespaced
@@ -0,0 +1,63 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falco
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FalcoRetentionPolicy sets how long the FalcoEvents matching its selector are kept.
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoRetentionPolicy struct {
+	metav1.TypeMeta
+	metav1.ObjectMeta
+	Spec   FalcoRetentionPolicySpec
+	Status FalcoRetentionPolicyStatus
+}
+
+type FalcoRetentionPolicySpec struct {
+	Precedence int32
+	Selector   FalcoEventSelector
+	TTL        *metav1.Duration
+	MaxCount   *int64
+	Archive    bool
+}
+
+type FalcoEventSelector struct {
+	NamespaceSelector *metav1.LabelSelector
+	Rules             []string
+	Priorities        []Priority
+	Sources           []string
+}
+
+type FalcoRetentionPolicyStatus struct {
+	ObservedGeneration int64
+	MatchedEvents      int64
+	DeletedEvents      int64
+	LastEvaluationTime *metav1.Time
+}
+
+// +genclient:nonNamespaced
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoRetentionPolicyList struct {
+	metav1.TypeMeta
+	metav1.ListMeta
+	Items []FalcoRetentionPolicy
+}
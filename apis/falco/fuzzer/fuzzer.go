@@ -17,12 +17,49 @@ limitations under the License.
 package fuzzer
 
 import (
+	"encoding/json"
+
 	"kubeops.dev/falco-ui-server/apis/falco"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
 	"sigs.k8s.io/randfill"
 )
 
+var (
+	stringFields = []string{
+		falco.FieldNamespaceName,
+		falco.FieldPodName,
+		falco.FieldPodUID,
+		falco.FieldContainerID,
+		falco.FieldContainerName,
+		falco.FieldContainerImageRepository,
+		falco.FieldContainerImageTag,
+		falco.FieldContainerImageDigest,
+		falco.FieldProcName,
+		falco.FieldProcExepath,
+		falco.FieldProcCmdline,
+		falco.FieldProcParentName,
+		falco.FieldProcCwd,
+		falco.FieldUserName,
+		falco.FieldUserLoginName,
+		falco.FieldFDName,
+		falco.FieldFDType,
+		falco.FieldFDL4Protocol,
+		falco.FieldFDClientIP,
+		falco.FieldFDServerIP,
+		"evt.type",
+	}
+	intFields = []string{
+		falco.FieldProcPID,
+		falco.FieldProcParentPID,
+		falco.FieldProcTTY,
+		falco.FieldUserUID,
+		falco.FieldFDClientPort,
+		falco.FieldFDServerPort,
+	}
+)
+
 // Funcs returns the fuzzer functions for this api group.
 var Funcs = func(codecs runtimeserializer.CodecFactory) []any {
 	return []any{
@@ -30,5 +67,27 @@ var Funcs = func(codecs runtimeserializer.CodecFactory) []any {
 		func(s *falco.FalcoEvent, c randfill.Continue) {
 			c.Fill(s) // fuzz self without calling this function again
 		},
+		func(s *falco.FalcoEventSpec, c randfill.Continue) {
+			c.FillNoCustom(s)
+
+			// the structured sections are always derived from the output fields
+			fields := map[string]any{}
+			for _, k := range stringFields {
+				if c.Bool() {
+					fields[k] = c.String(0)
+				}
+			}
+			for _, k := range intFields {
+				if c.Bool() {
+					fields[k] = c.Int63()
+				}
+			}
+			if c.Bool() {
+				fields[falco.FieldContainerPrivileged] = c.Bool()
+			}
+			raw, _ := json.Marshal(fields)
+			s.OutputFields = apiextensionsv1.JSON{Raw: raw}
+			_ = s.SetSectionsFromOutputFields()
+		},
 	}
 }
@@ -19,6 +19,7 @@ package install
 import (
 	"kubeops.dev/falco-ui-server/apis/falco"
 	"kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
@@ -28,4 +29,6 @@ import (
 func Install(scheme *runtime.Scheme) {
 	utilruntime.Must(falco.AddToScheme(scheme))
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
+	utilruntime.Must(v1beta1.AddToScheme(scheme))
+	utilruntime.Must(scheme.SetVersionPriority(v1beta1.SchemeGroupVersion, v1alpha1.SchemeGroupVersion))
 }
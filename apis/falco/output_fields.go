@@ -0,0 +1,182 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falco
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+)
+
+// Well known Falco output fields that are mapped to the structured sections of a FalcoEventSpec.
+const (
+	FieldNamespaceName = "k8s.ns.name"
+	FieldPodName       = "k8s.pod.name"
+	FieldPodUID        = "k8s.pod.uid"
+
+	FieldContainerID              = "container.id"
+	FieldContainerName            = "container.name"
+	FieldContainerImageRepository = "container.image.repository"
+	FieldContainerImageTag        = "container.image.tag"
+	FieldContainerImageDigest     = "container.image.digest"
+	FieldContainerPrivileged      = "container.privileged"
+
+	FieldProcName       = "proc.name"
+	FieldProcExepath    = "proc.exepath"
+	FieldProcCmdline    = "proc.cmdline"
+	FieldProcPID        = "proc.pid"
+	FieldProcParentName = "proc.pname"
+	FieldProcParentPID  = "proc.ppid"
+	FieldProcCwd        = "proc.cwd"
+	FieldProcTTY        = "proc.tty"
+	FieldUserName       = "user.name"
+	FieldUserUID        = "user.uid"
+	FieldUserLoginName  = "user.loginname"
+
+	FieldFDName       = "fd.name"
+	FieldFDType       = "fd.type"
+	FieldFDL4Protocol = "fd.l4proto"
+	FieldFDClientIP   = "fd.cip"
+	FieldFDClientPort = "fd.cport"
+	FieldFDServerIP   = "fd.sip"
+	FieldFDServerPort = "fd.sport"
+)
+
+// DecodeOutputFields decodes the raw output fields of a FalcoEvent. Numbers are kept as json.Number.
+func DecodeOutputFields(raw []byte) (map[string]any, error) {
+	fields := map[string]any{}
+	if len(raw) == 0 {
+		return fields, nil
+	}
+	d := json.NewDecoder(bytes.NewReader(raw))
+	d.UseNumber()
+	if err := d.Decode(&fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+// SetSectionsFromOutputFields fills the workload, container, process and network
+// sections of the spec from its output fields. Sections without any known field are set to nil.
+func (spec *FalcoEventSpec) SetSectionsFromOutputFields() error {
+	fields, err := DecodeOutputFields(spec.OutputFields.Raw)
+	if err != nil {
+		return err
+	}
+
+	workload := WorkloadInfo{
+		Namespace: stringField(fields, FieldNamespaceName),
+		Pod:       stringField(fields, FieldPodName),
+		PodUID:    stringField(fields, FieldPodUID),
+	}
+	spec.Workload = nil
+	if workload != (WorkloadInfo{}) {
+		spec.Workload = &workload
+	}
+
+	container := ContainerInfo{
+		ID:              stringField(fields, FieldContainerID),
+		Name:            stringField(fields, FieldContainerName),
+		ImageRepository: stringField(fields, FieldContainerImageRepository),
+		ImageTag:        stringField(fields, FieldContainerImageTag),
+		ImageDigest:     stringField(fields, FieldContainerImageDigest),
+		Privileged:      boolField(fields, FieldContainerPrivileged),
+	}
+	spec.Container = nil
+	if container != (ContainerInfo{}) {
+		spec.Container = &container
+	}
+
+	process := ProcessInfo{
+		Name:       stringField(fields, FieldProcName),
+		Executable: stringField(fields, FieldProcExepath),
+		Cmdline:    stringField(fields, FieldProcCmdline),
+		PID:        int64Field(fields, FieldProcPID),
+		ParentName: stringField(fields, FieldProcParentName),
+		ParentPID:  int64Field(fields, FieldProcParentPID),
+		Cwd:        stringField(fields, FieldProcCwd),
+		User:       stringField(fields, FieldUserName),
+		UID:        int64Field(fields, FieldUserUID),
+		LoginUser:  stringField(fields, FieldUserLoginName),
+		TTY:        int64Field(fields, FieldProcTTY),
+	}
+	spec.Process = nil
+	if process != (ProcessInfo{}) {
+		spec.Process = &process
+	}
+
+	network := NetworkInfo{
+		FDName:     stringField(fields, FieldFDName),
+		FDType:     stringField(fields, FieldFDType),
+		L4Protocol: stringField(fields, FieldFDL4Protocol),
+		ClientIP:   stringField(fields, FieldFDClientIP),
+		ClientPort: int64Field(fields, FieldFDClientPort),
+		ServerIP:   stringField(fields, FieldFDServerIP),
+		ServerPort: int64Field(fields, FieldFDServerPort),
+	}
+	spec.Network = nil
+	if network != (NetworkInfo{}) {
+		spec.Network = &network
+	}
+	return nil
+}
+
+func stringField(fields map[string]any, key string) string {
+	if v, ok := fields[key].(string); ok {
+		return v
+	}
+	return ""
+}
+
+func int64Field(fields map[string]any, key string) *int64 {
+	var n int64
+	var err error
+	switch v := fields[key].(type) {
+	case json.Number:
+		n, err = v.Int64()
+	case float64:
+		n = int64(v)
+	case int64:
+		n = v
+	case int:
+		n = int64(v)
+	case string:
+		n, err = strconv.ParseInt(v, 10, 64)
+	default:
+		return nil
+	}
+	if err != nil {
+		return nil
+	}
+	return &n
+}
+
+func boolField(fields map[string]any, key string) *bool {
+	var b bool
+	switch v := fields[key].(type) {
+	case bool:
+		b = v
+	case string:
+		var err error
+		if b, err = strconv.ParseBool(v); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return &b
+}
@@ -50,6 +50,10 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		SchemeGroupVersion,
 		&FalcoEvent{},
 		&FalcoEventList{},
+		&FalcoRetentionPolicy{},
+		&FalcoRetentionPolicyList{},
+		&FalcoAttackMatrix{},
+		&FalcoAttackMatrixList{},
 	)
 	return nil
 }
@@ -0,0 +1,95 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+)
+
+func Convert_v1_Time_To_v1_MicroTime(in *metav1.Time, out *metav1.MicroTime, s conversion.Scope) error {
+	out.Time = in.Time
+	return nil
+}
+
+func Convert_v1_MicroTime_To_v1_Time(in *metav1.MicroTime, out *metav1.Time, s conversion.Scope) error {
+	out.Time = in.Time
+	return nil
+}
+
+func Convert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(in *FalcoEvent, out *falco.FalcoEvent, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(in, out, s); err != nil {
+		return err
+	}
+
+	v, ok := in.Annotations[AnnotationEventTime]
+	if !ok {
+		return nil
+	}
+	annotations := make(map[string]string, len(in.Annotations))
+	for k, v := range in.Annotations {
+		annotations[k] = v
+	}
+	delete(annotations, AnnotationEventTime)
+	if len(annotations) == 0 {
+		annotations = nil
+	}
+	out.Annotations = annotations
+
+	// only trust the annotation if it was not left behind by a change to spec.time
+	if t, err := time.Parse(metav1.RFC3339Micro, v); err == nil && t.Truncate(time.Second).Equal(in.Spec.Time.Truncate(time.Second)) {
+		out.Spec.Time = metav1.NewMicroTime(t)
+	}
+	return nil
+}
+
+func Convert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(in *falco.FalcoEvent, out *FalcoEvent, s conversion.Scope) error {
+	if err := autoConvert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(in, out, s); err != nil {
+		return err
+	}
+
+	if in.Spec.Time.IsZero() || in.Spec.Time.Nanosecond() == 0 {
+		return nil
+	}
+	annotations := make(map[string]string, len(in.Annotations)+1)
+	for k, v := range in.Annotations {
+		annotations[k] = v
+	}
+	annotations[AnnotationEventTime] = in.Spec.Time.UTC().Format(metav1.RFC3339Micro)
+	out.Annotations = annotations
+	return nil
+}
+
+func Convert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(in *FalcoEventSpec, out *falco.FalcoEventSpec, s conversion.Scope) error {
+	if err := autoConvert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(in, out, s); err != nil {
+		return err
+	}
+	// malformed output fields are rejected by validation, so they can only
+	// come from old objects. Those are served without the structured sections.
+	_ = out.SetSectionsFromOutputFields()
+	return nil
+}
+
+// Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec drops the structured sections,
+// they are derived from the output fields on the way back.
+func Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(in *falco.FalcoEventSpec, out *FalcoEventSpec, s conversion.Scope) error {
+	return autoConvert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(in, out, s)
+}
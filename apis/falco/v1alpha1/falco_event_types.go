@@ -27,17 +27,24 @@ const (
 	ResourceFalcoEvents    = "falcoevents"
 )
 
+// AnnotationEventTime preserves the sub-second part of spec.time, which
+// can't be represented by metav1.Time, when an event is read through v1alpha1.
+const AnnotationEventTime = "falco.appscode.com/time"
+
 // +genclient
 // +genclient:nonNamespaced
 // +k8s:openapi-gen=true
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:storageversion
 type FalcoEvent struct {
 	metav1.TypeMeta `json:",inline"`
 	// Name will be formed by hashing the ImageRef + Tag + Digest
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	// Spec describes the attributes for the Image Scan SingleReport
+	// Spec describes the event as reported by Falco
 	Spec FalcoEventSpec `json:"spec,omitempty"`
+	// Status describes how often the event occurred and its triage state
+	Status FalcoEventStatus `json:"status,omitempty"`
 }
 
 type FalcoEventSpec struct {
@@ -53,6 +60,20 @@ type FalcoEventSpec struct {
 	Nodename     string               `json:"nodename,omitempty"`
 }
 
+type FalcoEventStatus struct {
+	// Count is the number of times this event occurred
+	// +optional
+	Count int64 `json:"count,omitempty"`
+	// FirstSeen is the time of the first occurrence of this event
+	// +optional
+	FirstSeen *metav1.MicroTime `json:"firstSeen,omitempty"`
+	// LastSeen is the time of the most recent occurrence of this event
+	// +optional
+	LastSeen *metav1.MicroTime `json:"lastSeen,omitempty"`
+	// +optional
+	TriageState string `json:"triageState,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 type FalcoEventList struct {
@@ -395,6 +395,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEvent":         schema_falco_ui_server_apis_falco_v1alpha1_FalcoEvent(ref),
 		"kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventList":     schema_falco_ui_server_apis_falco_v1alpha1_FalcoEventList(ref),
 		"kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventSpec":     schema_falco_ui_server_apis_falco_v1alpha1_FalcoEventSpec(ref),
+		"kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventStatus":   schema_falco_ui_server_apis_falco_v1alpha1_FalcoEventStatus(ref),
 	}
 }
 
@@ -20257,16 +20258,23 @@ func schema_falco_ui_server_apis_falco_v1alpha1_FalcoEvent(ref common.ReferenceC
 					},
 					"spec": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Spec describes the attributes for the Image Scan SingleReport",
+							Description: "Spec describes the event as reported by Falco",
 							Default:     map[string]interface{}{},
 							Ref:         ref("kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventSpec"),
 						},
 					},
+					"status": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Status describes how often the event occurred and its triage state",
+							Default:     map[string]interface{}{},
+							Ref:         ref("kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventStatus"),
+						},
+					},
 				},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.ObjectMeta", "kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventSpec", "kubeops.dev/falco-ui-server/apis/falco/v1alpha1.FalcoEventStatus"},
 	}
 }
 
@@ -20402,3 +20410,42 @@ func schema_falco_ui_server_apis_falco_v1alpha1_FalcoEventSpec(ref common.Refere
 			"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
 	}
 }
+
+func schema_falco_ui_server_apis_falco_v1alpha1_FalcoEventStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Type: []string{"object"},
+				Properties: map[string]spec.Schema{
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of times this event occurred",
+							Type:        []string{"integer"},
+							Format:      "int64",
+						},
+					},
+					"firstSeen": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FirstSeen is the time of the first occurrence of this event",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"),
+						},
+					},
+					"lastSeen": {
+						SchemaProps: spec.SchemaProps{
+							Description: "LastSeen is the time of the most recent occurrence of this event",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"),
+						},
+					},
+					"triageState": {
+						SchemaProps: spec.SchemaProps{
+							Type:   []string{"string"},
+							Format: "",
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.MicroTime"},
+	}
+}
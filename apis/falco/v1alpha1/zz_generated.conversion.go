@@ -26,6 +26,7 @@ import (
 
 	falco "kubeops.dev/falco-ui-server/apis/falco"
 
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	conversion "k8s.io/apimachinery/pkg/conversion"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -37,33 +38,53 @@ func init() {
 // RegisterConversions adds conversion functions to the given scheme.
 // Public to allow building arbitrary schemes.
 func RegisterConversions(s *runtime.Scheme) error {
-	if err := s.AddGeneratedConversionFunc((*FalcoEvent)(nil), (*falco.FalcoEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(a.(*FalcoEvent), b.(*falco.FalcoEvent), scope)
+	if err := s.AddGeneratedConversionFunc((*FalcoEventList)(nil), (*falco.FalcoEventList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FalcoEventList_To_falco_FalcoEventList(a.(*FalcoEventList), b.(*falco.FalcoEventList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*falco.FalcoEventList)(nil), (*FalcoEventList)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_falco_FalcoEventList_To_v1alpha1_FalcoEventList(a.(*falco.FalcoEventList), b.(*FalcoEventList), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*FalcoEventStatus)(nil), (*falco.FalcoEventStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FalcoEventStatus_To_falco_FalcoEventStatus(a.(*FalcoEventStatus), b.(*falco.FalcoEventStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddGeneratedConversionFunc((*falco.FalcoEventStatus)(nil), (*FalcoEventStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_falco_FalcoEventStatus_To_v1alpha1_FalcoEventStatus(a.(*falco.FalcoEventStatus), b.(*FalcoEventStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*falco.FalcoEventSpec)(nil), (*FalcoEventSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(a.(*falco.FalcoEventSpec), b.(*FalcoEventSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*falco.FalcoEvent)(nil), (*FalcoEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
+	if err := s.AddConversionFunc((*falco.FalcoEvent)(nil), (*FalcoEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(a.(*falco.FalcoEvent), b.(*FalcoEvent), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*FalcoEventList)(nil), (*falco.FalcoEventList)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha1_FalcoEventList_To_falco_FalcoEventList(a.(*FalcoEventList), b.(*falco.FalcoEventList), scope)
+	if err := s.AddConversionFunc((*v1.MicroTime)(nil), (*v1.Time)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_MicroTime_To_v1_Time(a.(*v1.MicroTime), b.(*v1.Time), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*falco.FalcoEventList)(nil), (*FalcoEventList)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_falco_FalcoEventList_To_v1alpha1_FalcoEventList(a.(*falco.FalcoEventList), b.(*FalcoEventList), scope)
+	if err := s.AddConversionFunc((*v1.Time)(nil), (*v1.MicroTime)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1_Time_To_v1_MicroTime(a.(*v1.Time), b.(*v1.MicroTime), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*FalcoEventSpec)(nil), (*falco.FalcoEventSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+	if err := s.AddConversionFunc((*FalcoEventSpec)(nil), (*falco.FalcoEventSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(a.(*FalcoEventSpec), b.(*falco.FalcoEventSpec), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*falco.FalcoEventSpec)(nil), (*FalcoEventSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(a.(*falco.FalcoEventSpec), b.(*FalcoEventSpec), scope)
+	if err := s.AddConversionFunc((*FalcoEvent)(nil), (*falco.FalcoEvent)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(a.(*FalcoEvent), b.(*falco.FalcoEvent), scope)
 	}); err != nil {
 		return err
 	}
@@ -75,30 +96,36 @@ func autoConvert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(in *FalcoEvent, out *fa
 	if err := Convert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	if err := Convert_v1alpha1_FalcoEventStatus_To_falco_FalcoEventStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
-// Convert_v1alpha1_FalcoEvent_To_falco_FalcoEvent is an autogenerated conversion function.
-func Convert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(in *FalcoEvent, out *falco.FalcoEvent, s conversion.Scope) error {
-	return autoConvert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(in, out, s)
-}
-
 func autoConvert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(in *falco.FalcoEvent, out *FalcoEvent, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(&in.Spec, &out.Spec, s); err != nil {
 		return err
 	}
+	if err := Convert_falco_FalcoEventStatus_To_v1alpha1_FalcoEventStatus(&in.Status, &out.Status, s); err != nil {
+		return err
+	}
 	return nil
 }
 
-// Convert_falco_FalcoEvent_To_v1alpha1_FalcoEvent is an autogenerated conversion function.
-func Convert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(in *falco.FalcoEvent, out *FalcoEvent, s conversion.Scope) error {
-	return autoConvert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(in, out, s)
-}
-
 func autoConvert_v1alpha1_FalcoEventList_To_falco_FalcoEventList(in *FalcoEventList, out *falco.FalcoEventList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]falco.FalcoEvent)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]falco.FalcoEvent, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha1_FalcoEvent_To_falco_FalcoEvent(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -109,7 +136,17 @@ func Convert_v1alpha1_FalcoEventList_To_falco_FalcoEventList(in *FalcoEventList,
 
 func autoConvert_falco_FalcoEventList_To_v1alpha1_FalcoEventList(in *falco.FalcoEventList, out *FalcoEventList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]FalcoEvent)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FalcoEvent, len(*in))
+		for i := range *in {
+			if err := Convert_falco_FalcoEvent_To_v1alpha1_FalcoEvent(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -121,9 +158,11 @@ func Convert_falco_FalcoEventList_To_v1alpha1_FalcoEventList(in *falco.FalcoEven
 func autoConvert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(in *FalcoEventSpec, out *falco.FalcoEventSpec, s conversion.Scope) error {
 	out.UUID = in.UUID
 	out.Output = in.Output
-	out.Priority = in.Priority
+	out.Priority = falco.Priority(in.Priority)
 	out.Rule = in.Rule
-	out.Time = in.Time
+	if err := Convert_v1_Time_To_v1_MicroTime(&in.Time, &out.Time, s); err != nil {
+		return err
+	}
 	out.OutputFields = in.OutputFields
 	out.Source = in.Source
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
@@ -132,26 +171,48 @@ func autoConvert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(in *FalcoEventS
 	return nil
 }
 
-// Convert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec is an autogenerated conversion function.
-func Convert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(in *FalcoEventSpec, out *falco.FalcoEventSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha1_FalcoEventSpec_To_falco_FalcoEventSpec(in, out, s)
-}
-
 func autoConvert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(in *falco.FalcoEventSpec, out *FalcoEventSpec, s conversion.Scope) error {
 	out.UUID = in.UUID
 	out.Output = in.Output
-	out.Priority = in.Priority
+	out.Priority = string(in.Priority)
 	out.Rule = in.Rule
-	out.Time = in.Time
+	if err := Convert_v1_MicroTime_To_v1_Time(&in.Time, &out.Time, s); err != nil {
+		return err
+	}
 	out.OutputFields = in.OutputFields
 	out.Source = in.Source
 	out.Tags = *(*[]string)(unsafe.Pointer(&in.Tags))
 	out.Hostname = in.Hostname
 	out.Nodename = in.Nodename
+	// WARNING: in.Workload requires manual conversion: does not exist in peer-type
+	// WARNING: in.Container requires manual conversion: does not exist in peer-type
+	// WARNING: in.Process requires manual conversion: does not exist in peer-type
+	// WARNING: in.Network requires manual conversion: does not exist in peer-type
+	return nil
+}
+
+func autoConvert_v1alpha1_FalcoEventStatus_To_falco_FalcoEventStatus(in *FalcoEventStatus, out *falco.FalcoEventStatus, s conversion.Scope) error {
+	out.Count = in.Count
+	out.FirstSeen = (*v1.MicroTime)(unsafe.Pointer(in.FirstSeen))
+	out.LastSeen = (*v1.MicroTime)(unsafe.Pointer(in.LastSeen))
+	out.TriageState = falco.TriageState(in.TriageState)
+	return nil
+}
+
+// Convert_v1alpha1_FalcoEventStatus_To_falco_FalcoEventStatus is an autogenerated conversion function.
+func Convert_v1alpha1_FalcoEventStatus_To_falco_FalcoEventStatus(in *FalcoEventStatus, out *falco.FalcoEventStatus, s conversion.Scope) error {
+	return autoConvert_v1alpha1_FalcoEventStatus_To_falco_FalcoEventStatus(in, out, s)
+}
+
+func autoConvert_falco_FalcoEventStatus_To_v1alpha1_FalcoEventStatus(in *falco.FalcoEventStatus, out *FalcoEventStatus, s conversion.Scope) error {
+	out.Count = in.Count
+	out.FirstSeen = (*v1.MicroTime)(unsafe.Pointer(in.FirstSeen))
+	out.LastSeen = (*v1.MicroTime)(unsafe.Pointer(in.LastSeen))
+	out.TriageState = string(in.TriageState)
 	return nil
 }
 
-// Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec is an autogenerated conversion function.
-func Convert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(in *falco.FalcoEventSpec, out *FalcoEventSpec, s conversion.Scope) error {
-	return autoConvert_falco_FalcoEventSpec_To_v1alpha1_FalcoEventSpec(in, out, s)
+// Convert_falco_FalcoEventStatus_To_v1alpha1_FalcoEventStatus is an autogenerated conversion function.
+func Convert_falco_FalcoEventStatus_To_v1alpha1_FalcoEventStatus(in *falco.FalcoEventStatus, out *FalcoEventStatus, s conversion.Scope) error {
+	return autoConvert_falco_FalcoEventStatus_To_v1alpha1_FalcoEventStatus(in, out, s)
 }
@@ -31,6 +31,7 @@ func (in *FalcoEvent) DeepCopyInto(out *FalcoEvent) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -107,3 +108,27 @@ func (in *FalcoEventSpec) DeepCopy() *FalcoEventSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoEventStatus) DeepCopyInto(out *FalcoEventStatus) {
+	*out = *in
+	if in.FirstSeen != nil {
+		in, out := &in.FirstSeen, &out.FirstSeen
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSeen != nil {
+		in, out := &in.LastSeen, &out.LastSeen
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoEventStatus.
+func (in *FalcoEventStatus) DeepCopy() *FalcoEventStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoEventStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,132 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindFalcoAttackMatrix = "FalcoAttackMatrix"
+	ResourceFalcoAttackMatrix     = "falcoattackmatrix"
+	ResourceFalcoAttackMatrices   = "falcoattackmatrices"
+)
+
+// FalcoAttackMatrix is a read-only view of the stored FalcoEvents by MITRE ATT&CK tactic and
+// technique, as mapped from the tags of their rules. The name is the time window ending now the
+// matrix covers, eg, 1h, 24h or 7d.
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:onlyVerbs=get,list
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoAttackMatrix struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   FalcoAttackMatrixSpec   `json:"spec,omitempty"`
+	Status FalcoAttackMatrixStatus `json:"status,omitempty"`
+}
+
+type FalcoAttackMatrixSpec struct {
+	// Window is the time span ending now covered by the matrix
+	Window metav1.Duration `json:"window"`
+}
+
+type FalcoAttackMatrixStatus struct {
+	// Since is the start of the window, rounded down to the time buckets of the event index
+	Since metav1.Time `json:"since"`
+	// Until is the time the matrix was computed at
+	Until metav1.Time `json:"until"`
+	// Events is the number of events in the window
+	// +optional
+	Events int64 `json:"events,omitempty"`
+	// UnmappedEvents is the number of events in the window whose rule has no ATT&CK tags
+	// +optional
+	UnmappedEvents int64 `json:"unmappedEvents,omitempty"`
+	// Tactics lists every ATT&CK tactic in the order of the matrix, including the tactics without events
+	// +optional
+	Tactics []AttackTactic `json:"tactics,omitempty"`
+}
+
+type AttackTactic struct {
+	// ID is the ATT&CK tactic ID, eg, TA0002
+	ID string `json:"id"`
+	// Name is the ATT&CK tactic name, eg, Execution
+	Name string `json:"name"`
+	// Events is the number of events of the tactic
+	// +optional
+	Events int64 `json:"events,omitempty"`
+	// Occurrences is the sum of the occurrences of the events of the tactic
+	// +optional
+	Occurrences int64 `json:"occurrences,omitempty"`
+	// Techniques lists the techniques of the tactic with events, the most events first
+	// +optional
+	Techniques []AttackTechnique `json:"techniques,omitempty"`
+}
+
+type AttackTechnique struct {
+	// ID is the ATT&CK technique or sub-technique ID, eg, T1059.004
+	ID string `json:"id"`
+	// Name is the ATT&CK technique name, empty for IDs missing in the bundled mapping
+	// +optional
+	Name string `json:"name,omitempty"`
+	// Events is the number of events of the technique
+	Events int64 `json:"events"`
+	// Occurrences is the sum of the occurrences of the events of the technique
+	// +optional
+	Occurrences int64 `json:"occurrences,omitempty"`
+	// Rules are the Falco rules the events of the technique were raised by
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+	// LatestEvents are the most recent events of the technique
+	// +optional
+	LatestEvents []AttackEventReference `json:"latestEvents,omitempty"`
+	// Workloads are the workloads and hosts with the most events of the technique
+	// +optional
+	Workloads []AttackWorkload `json:"workloads,omitempty"`
+}
+
+// AttackEventReference references a FalcoEvent.
+type AttackEventReference struct {
+	Name string `json:"name"`
+	// +optional
+	UUID string `json:"uuid,omitempty"`
+	// +optional
+	Rule string           `json:"rule,omitempty"`
+	Time metav1.MicroTime `json:"time"`
+}
+
+// AttackWorkload identifies the workload of container events, or the node of host events.
+type AttackWorkload struct {
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+	// +optional
+	Name string `json:"name,omitempty"`
+	// +optional
+	Node   string `json:"node,omitempty"`
+	Events int64  `json:"events"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type FalcoAttackMatrixList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FalcoAttackMatrix `json:"items,omitempty"`
+}
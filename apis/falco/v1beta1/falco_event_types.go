@@ -0,0 +1,168 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindFalcoEvent = "FalcoEvent"
+	ResourceFalcoEvent     = "falcoevent"
+	ResourceFalcoEvents    = "falcoevents"
+)
+
+const (
+	// AnnotationTruncatedFields lists the fields of a FalcoEvent that were truncated to fit the size limits.
+	AnnotationTruncatedFields = "falco.appscode.com/truncated"
+	// AnnotationRestoredAt is set to the RFC 3339 time a FalcoEvent was restored from the archive.
+	AnnotationRestoredAt = "falco.appscode.com/restored-at"
+	// AnnotationRecurrence is set to "true" on the FalcoEvents sent to the outputs for a recurrence of a stored FalcoEvent.
+	AnnotationRecurrence = "falco.appscode.com/recurrence"
+)
+
+// FalcoEvent is a security event reported by Falco.
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoEvent struct {
+	metav1.TypeMeta `json:",inline"`
+	// Name is formed by hashing the rule, priority, source, hostname and the identifying output fields
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// Spec describes the event as reported by Falco
+	Spec FalcoEventSpec `json:"spec,omitempty"`
+	// Status describes how often the event occurred and its triage state
+	Status FalcoEventStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Emergency;Alert;Critical;Error;Warning;Notice;Informational;Debug
+type Priority string
+
+const (
+	PriorityEmergency     Priority = "Emergency"
+	PriorityAlert         Priority = "Alert"
+	PriorityCritical      Priority = "Critical"
+	PriorityError         Priority = "Error"
+	PriorityWarning       Priority = "Warning"
+	PriorityNotice        Priority = "Notice"
+	PriorityInformational Priority = "Informational"
+	PriorityDebug         Priority = "Debug"
+)
+
+type FalcoEventSpec struct {
+	UUID     string   `json:"uuid,omitempty"`
+	Output   string   `json:"output"`
+	Priority Priority `json:"priority"`
+	Rule     string   `json:"rule"`
+	// Time is the time Falco observed the event, with microsecond precision
+	Time     metav1.MicroTime `json:"time"`
+	Source   string           `json:"source"`
+	Tags     []string         `json:"tags,omitempty"`
+	Hostname string           `json:"hostname,omitempty"`
+	Nodename string           `json:"nodename,omitempty"`
+
+	// Workload identifies the pod the event was raised for
+	// +optional
+	Workload *WorkloadInfo `json:"workload,omitempty"`
+	// Container identifies the container the event was raised for
+	// +optional
+	Container *ContainerInfo `json:"container,omitempty"`
+	// Process describes the process that triggered the event
+	// +optional
+	Process *ProcessInfo `json:"process,omitempty"`
+	// Network describes the file descriptor or connection involved in the event
+	// +optional
+	Network *NetworkInfo `json:"network,omitempty"`
+
+	// OutputFields holds all output fields as sent by Falco
+	OutputFields apiextensionsv1.JSON `json:"outputFields"`
+}
+
+type WorkloadInfo struct {
+	Namespace string `json:"namespace,omitempty"`
+	Pod       string `json:"pod,omitempty"`
+	PodUID    string `json:"podUID,omitempty"`
+}
+
+type ContainerInfo struct {
+	ID              string `json:"id,omitempty"`
+	Name            string `json:"name,omitempty"`
+	ImageRepository string `json:"imageRepository,omitempty"`
+	ImageTag        string `json:"imageTag,omitempty"`
+	ImageDigest     string `json:"imageDigest,omitempty"`
+	Privileged      *bool  `json:"privileged,omitempty"`
+}
+
+type ProcessInfo struct {
+	Name       string `json:"name,omitempty"`
+	Executable string `json:"executable,omitempty"`
+	Cmdline    string `json:"cmdline,omitempty"`
+	PID        *int64 `json:"pid,omitempty"`
+	ParentName string `json:"parentName,omitempty"`
+	ParentPID  *int64 `json:"parentPID,omitempty"`
+	Cwd        string `json:"cwd,omitempty"`
+	User       string `json:"user,omitempty"`
+	UID        *int64 `json:"uid,omitempty"`
+	LoginUser  string `json:"loginUser,omitempty"`
+	TTY        *int64 `json:"tty,omitempty"`
+}
+
+type NetworkInfo struct {
+	FDName     string `json:"fdName,omitempty"`
+	FDType     string `json:"fdType,omitempty"`
+	L4Protocol string `json:"l4Protocol,omitempty"`
+	ClientIP   string `json:"clientIP,omitempty"`
+	ClientPort *int64 `json:"clientPort,omitempty"`
+	ServerIP   string `json:"serverIP,omitempty"`
+	ServerPort *int64 `json:"serverPort,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Open;Acknowledged;Resolved;FalsePositive
+type TriageState string
+
+const (
+	TriageStateOpen          TriageState = "Open"
+	TriageStateAcknowledged  TriageState = "Acknowledged"
+	TriageStateResolved      TriageState = "Resolved"
+	TriageStateFalsePositive TriageState = "FalsePositive"
+)
+
+type FalcoEventStatus struct {
+	// Count is the number of times this event occurred
+	// +optional
+	Count int64 `json:"count,omitempty"`
+	// FirstSeen is the time of the first occurrence of this event
+	// +optional
+	FirstSeen *metav1.MicroTime `json:"firstSeen,omitempty"`
+	// LastSeen is the time of the most recent occurrence of this event
+	// +optional
+	LastSeen *metav1.MicroTime `json:"lastSeen,omitempty"`
+	// +optional
+	TriageState TriageState `json:"triageState,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type FalcoEventList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FalcoEvent `json:"items,omitempty"`
+}
@@ -0,0 +1,99 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	ResourceKindFalcoRetentionPolicy = "FalcoRetentionPolicy"
+	ResourceFalcoRetentionPolicy     = "falcoretentionpolicy"
+	ResourceFalcoRetentionPolicies   = "falcoretentionpolicies"
+)
+
+// FalcoRetentionPolicy sets how long the FalcoEvents matching its selector are kept.
+
+// +genclient
+// +genclient:nonNamespaced
+// +k8s:openapi-gen=true
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FalcoRetentionPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec FalcoRetentionPolicySpec `json:"spec,omitempty"`
+	// Status reports the events matched and deleted by the last cleaner pass
+	Status FalcoRetentionPolicyStatus `json:"status,omitempty"`
+}
+
+type FalcoRetentionPolicySpec struct {
+	// Precedence orders the policies. An event is governed by the matching policy with the
+	// lowest precedence, ties are broken by name. Events matching no policy are kept
+	// according to the server flags.
+	// +optional
+	Precedence int32 `json:"precedence,omitempty"`
+	// Selector selects the events of the policy. An empty selector matches all events.
+	// +optional
+	Selector FalcoEventSelector `json:"selector,omitempty"`
+	// TTL is the time the events are kept for
+	// +optional
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+	// MaxCount is the maximum number of events kept, the oldest events are deleted first
+	// +optional
+	MaxCount *int64 `json:"maxCount,omitempty"`
+	// Archive archives the events before they are deleted, if the server has an archive configured
+	// +optional
+	Archive bool `json:"archive,omitempty"`
+}
+
+// FalcoEventSelector matches the FalcoEvents meeting all of its non-empty criteria.
+type FalcoEventSelector struct {
+	// NamespaceSelector matches the labels of the namespace of an event. Host events,
+	// ie, events without a namespace, never match.
+	// +optional
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	// +optional
+	Rules []string `json:"rules,omitempty"`
+	// +optional
+	Priorities []Priority `json:"priorities,omitempty"`
+	// +optional
+	Sources []string `json:"sources,omitempty"`
+}
+
+type FalcoRetentionPolicyStatus struct {
+	// ObservedGeneration is the generation of the spec the last pass evaluated
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+	// MatchedEvents is the number of events governed by the policy in the last pass
+	// +optional
+	MatchedEvents int64 `json:"matchedEvents,omitempty"`
+	// DeletedEvents is the number of events deleted by the policy in the last pass
+	// +optional
+	DeletedEvents int64 `json:"deletedEvents,omitempty"`
+	// LastEvaluationTime is the time of the last pass
+	// +optional
+	LastEvaluationTime *metav1.Time `json:"lastEvaluationTime,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type FalcoRetentionPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []FalcoRetentionPolicy `json:"items,omitempty"`
+}
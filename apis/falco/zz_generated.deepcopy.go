@@ -22,15 +22,230 @@ limitations under the License.
 package falco
 
 import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttackEventReference) DeepCopyInto(out *AttackEventReference) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttackEventReference.
+func (in *AttackEventReference) DeepCopy() *AttackEventReference {
+	if in == nil {
+		return nil
+	}
+	out := new(AttackEventReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttackTactic) DeepCopyInto(out *AttackTactic) {
+	*out = *in
+	if in.Techniques != nil {
+		in, out := &in.Techniques, &out.Techniques
+		*out = make([]AttackTechnique, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttackTactic.
+func (in *AttackTactic) DeepCopy() *AttackTactic {
+	if in == nil {
+		return nil
+	}
+	out := new(AttackTactic)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttackTechnique) DeepCopyInto(out *AttackTechnique) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LatestEvents != nil {
+		in, out := &in.LatestEvents, &out.LatestEvents
+		*out = make([]AttackEventReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Workloads != nil {
+		in, out := &in.Workloads, &out.Workloads
+		*out = make([]AttackWorkload, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttackTechnique.
+func (in *AttackTechnique) DeepCopy() *AttackTechnique {
+	if in == nil {
+		return nil
+	}
+	out := new(AttackTechnique)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AttackWorkload) DeepCopyInto(out *AttackWorkload) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AttackWorkload.
+func (in *AttackWorkload) DeepCopy() *AttackWorkload {
+	if in == nil {
+		return nil
+	}
+	out := new(AttackWorkload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerInfo) DeepCopyInto(out *ContainerInfo) {
+	*out = *in
+	if in.Privileged != nil {
+		in, out := &in.Privileged, &out.Privileged
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerInfo.
+func (in *ContainerInfo) DeepCopy() *ContainerInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoAttackMatrix) DeepCopyInto(out *FalcoAttackMatrix) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoAttackMatrix.
+func (in *FalcoAttackMatrix) DeepCopy() *FalcoAttackMatrix {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoAttackMatrix)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FalcoAttackMatrix) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoAttackMatrixList) DeepCopyInto(out *FalcoAttackMatrixList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FalcoAttackMatrix, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoAttackMatrixList.
+func (in *FalcoAttackMatrixList) DeepCopy() *FalcoAttackMatrixList {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoAttackMatrixList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FalcoAttackMatrixList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoAttackMatrixSpec) DeepCopyInto(out *FalcoAttackMatrixSpec) {
+	*out = *in
+	out.Window = in.Window
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoAttackMatrixSpec.
+func (in *FalcoAttackMatrixSpec) DeepCopy() *FalcoAttackMatrixSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoAttackMatrixSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoAttackMatrixStatus) DeepCopyInto(out *FalcoAttackMatrixStatus) {
+	*out = *in
+	in.Since.DeepCopyInto(&out.Since)
+	in.Until.DeepCopyInto(&out.Until)
+	if in.Tactics != nil {
+		in, out := &in.Tactics, &out.Tactics
+		*out = make([]AttackTactic, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoAttackMatrixStatus.
+func (in *FalcoAttackMatrixStatus) DeepCopy() *FalcoAttackMatrixStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoAttackMatrixStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalcoEvent) DeepCopyInto(out *FalcoEvent) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -85,6 +300,42 @@ func (in *FalcoEventList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoEventSelector) DeepCopyInto(out *FalcoEventSelector) {
+	*out = *in
+	if in.NamespaceSelector != nil {
+		in, out := &in.NamespaceSelector, &out.NamespaceSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Priorities != nil {
+		in, out := &in.Priorities, &out.Priorities
+		*out = make([]Priority, len(*in))
+		copy(*out, *in)
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoEventSelector.
+func (in *FalcoEventSelector) DeepCopy() *FalcoEventSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoEventSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FalcoEventSpec) DeepCopyInto(out *FalcoEventSpec) {
 	*out = *in
@@ -95,6 +346,26 @@ func (in *FalcoEventSpec) DeepCopyInto(out *FalcoEventSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Workload != nil {
+		in, out := &in.Workload, &out.Workload
+		*out = new(WorkloadInfo)
+		**out = **in
+	}
+	if in.Container != nil {
+		in, out := &in.Container, &out.Container
+		*out = new(ContainerInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Process != nil {
+		in, out := &in.Process, &out.Process
+		*out = new(ProcessInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(NetworkInfo)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -107,3 +378,213 @@ func (in *FalcoEventSpec) DeepCopy() *FalcoEventSpec {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoEventStatus) DeepCopyInto(out *FalcoEventStatus) {
+	*out = *in
+	if in.FirstSeen != nil {
+		in, out := &in.FirstSeen, &out.FirstSeen
+		*out = (*in).DeepCopy()
+	}
+	if in.LastSeen != nil {
+		in, out := &in.LastSeen, &out.LastSeen
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoEventStatus.
+func (in *FalcoEventStatus) DeepCopy() *FalcoEventStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoEventStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoRetentionPolicy) DeepCopyInto(out *FalcoRetentionPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoRetentionPolicy.
+func (in *FalcoRetentionPolicy) DeepCopy() *FalcoRetentionPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoRetentionPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FalcoRetentionPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoRetentionPolicyList) DeepCopyInto(out *FalcoRetentionPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FalcoRetentionPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoRetentionPolicyList.
+func (in *FalcoRetentionPolicyList) DeepCopy() *FalcoRetentionPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoRetentionPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FalcoRetentionPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoRetentionPolicySpec) DeepCopyInto(out *FalcoRetentionPolicySpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoRetentionPolicySpec.
+func (in *FalcoRetentionPolicySpec) DeepCopy() *FalcoRetentionPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoRetentionPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FalcoRetentionPolicyStatus) DeepCopyInto(out *FalcoRetentionPolicyStatus) {
+	*out = *in
+	if in.LastEvaluationTime != nil {
+		in, out := &in.LastEvaluationTime, &out.LastEvaluationTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FalcoRetentionPolicyStatus.
+func (in *FalcoRetentionPolicyStatus) DeepCopy() *FalcoRetentionPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FalcoRetentionPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkInfo) DeepCopyInto(out *NetworkInfo) {
+	*out = *in
+	if in.ClientPort != nil {
+		in, out := &in.ClientPort, &out.ClientPort
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ServerPort != nil {
+		in, out := &in.ServerPort, &out.ServerPort
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkInfo.
+func (in *NetworkInfo) DeepCopy() *NetworkInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProcessInfo) DeepCopyInto(out *ProcessInfo) {
+	*out = *in
+	if in.PID != nil {
+		in, out := &in.PID, &out.PID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ParentPID != nil {
+		in, out := &in.ParentPID, &out.ParentPID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.UID != nil {
+		in, out := &in.UID, &out.UID
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TTY != nil {
+		in, out := &in.TTY, &out.TTY
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProcessInfo.
+func (in *ProcessInfo) DeepCopy() *ProcessInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(ProcessInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WorkloadInfo) DeepCopyInto(out *WorkloadInfo) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkloadInfo.
+func (in *WorkloadInfo) DeepCopy() *WorkloadInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(WorkloadInfo)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -26,17 +26,20 @@ import (
 	rest "k8s.io/client-go/rest"
 	flowcontrol "k8s.io/client-go/util/flowcontrol"
 	falcov1alpha1 "kubeops.dev/falco-ui-server/client/clientset/versioned/typed/falco/v1alpha1"
+	falcov1beta1 "kubeops.dev/falco-ui-server/client/clientset/versioned/typed/falco/v1beta1"
 )
 
 type Interface interface {
 	Discovery() discovery.DiscoveryInterface
 	FalcoV1alpha1() falcov1alpha1.FalcoV1alpha1Interface
+	FalcoV1beta1() falcov1beta1.FalcoV1beta1Interface
 }
 
 // Clientset contains the clients for groups.
 type Clientset struct {
 	*discovery.DiscoveryClient
 	falcoV1alpha1 *falcov1alpha1.FalcoV1alpha1Client
+	falcoV1beta1  *falcov1beta1.FalcoV1beta1Client
 }
 
 // FalcoV1alpha1 retrieves the FalcoV1alpha1Client
@@ -44,6 +47,11 @@ func (c *Clientset) FalcoV1alpha1() falcov1alpha1.FalcoV1alpha1Interface {
 	return c.falcoV1alpha1
 }
 
+// FalcoV1beta1 retrieves the FalcoV1beta1Client
+func (c *Clientset) FalcoV1beta1() falcov1beta1.FalcoV1beta1Interface {
+	return c.falcoV1beta1
+}
+
 // Discovery retrieves the DiscoveryClient
 func (c *Clientset) Discovery() discovery.DiscoveryInterface {
 	if c == nil {
@@ -92,6 +100,10 @@ func NewForConfigAndClient(c *rest.Config, httpClient *http.Client) (*Clientset,
 	if err != nil {
 		return nil, err
 	}
+	cs.falcoV1beta1, err = falcov1beta1.NewForConfigAndClient(&configShallowCopy, httpClient)
+	if err != nil {
+		return nil, err
+	}
 
 	cs.DiscoveryClient, err = discovery.NewDiscoveryClientForConfigAndClient(&configShallowCopy, httpClient)
 	if err != nil {
@@ -114,6 +126,7 @@ func NewForConfigOrDie(c *rest.Config) *Clientset {
 func New(c rest.Interface) *Clientset {
 	var cs Clientset
 	cs.falcoV1alpha1 = falcov1alpha1.New(c)
+	cs.falcoV1beta1 = falcov1beta1.New(c)
 
 	cs.DiscoveryClient = discovery.NewDiscoveryClient(c)
 	return &cs
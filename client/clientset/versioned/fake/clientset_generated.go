@@ -27,6 +27,8 @@ import (
 	clientset "kubeops.dev/falco-ui-server/client/clientset/versioned"
 	falcov1alpha1 "kubeops.dev/falco-ui-server/client/clientset/versioned/typed/falco/v1alpha1"
 	fakefalcov1alpha1 "kubeops.dev/falco-ui-server/client/clientset/versioned/typed/falco/v1alpha1/fake"
+	falcov1beta1 "kubeops.dev/falco-ui-server/client/clientset/versioned/typed/falco/v1beta1"
+	fakefalcov1beta1 "kubeops.dev/falco-ui-server/client/clientset/versioned/typed/falco/v1beta1/fake"
 )
 
 // NewSimpleClientset returns a clientset that will respond with the provided objects.
@@ -83,3 +85,8 @@ var (
 func (c *Clientset) FalcoV1alpha1() falcov1alpha1.FalcoV1alpha1Interface {
 	return &fakefalcov1alpha1.FakeFalcoV1alpha1{Fake: &c.Fake}
 }
+
+// FalcoV1beta1 retrieves the FalcoV1beta1Client
+func (c *Clientset) FalcoV1beta1() falcov1beta1.FalcoV1beta1Interface {
+	return &fakefalcov1beta1.FakeFalcoV1beta1{Fake: &c.Fake}
+}
@@ -23,6 +23,7 @@ import (
 
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	types "k8s.io/apimachinery/pkg/types"
 	watch "k8s.io/apimachinery/pkg/watch"
 	testing "k8s.io/client-go/testing"
@@ -34,9 +35,9 @@ type FakeFalcoEvents struct {
 	Fake *FakeFalcoV1alpha1
 }
 
-var falcoeventsResource = v1alpha1.SchemeGroupVersion.WithResource("falcoevents")
+var falcoeventsResource = schema.GroupVersionResource{Group: "falco.appscode.com", Version: "v1alpha1", Resource: "falcoevents"}
 
-var falcoeventsKind = v1alpha1.SchemeGroupVersion.WithKind("FalcoEvent")
+var falcoeventsKind = schema.GroupVersionKind{Group: "falco.appscode.com", Version: "v1alpha1", Kind: "FalcoEvent"}
 
 // Get takes name of the falcoEvent, and returns the corresponding falcoEvent object, and an error if there is any.
 func (c *FakeFalcoEvents) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.FalcoEvent, err error) {
@@ -95,6 +96,17 @@ func (c *FakeFalcoEvents) Update(ctx context.Context, falcoEvent *v1alpha1.Falco
 	return obj.(*v1alpha1.FalcoEvent), err
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeFalcoEvents) UpdateStatus(ctx context.Context, falcoEvent *v1alpha1.FalcoEvent, opts v1.UpdateOptions) (*v1alpha1.FalcoEvent, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateSubresourceAction(falcoeventsResource, "status", falcoEvent), &v1alpha1.FalcoEvent{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.FalcoEvent), err
+}
+
 // Delete takes name of the falcoEvent and deletes it. Returns an error if one occurs.
 func (c *FakeFalcoEvents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	_, err := c.Fake.
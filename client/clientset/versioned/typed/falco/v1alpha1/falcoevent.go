@@ -40,6 +40,7 @@ type FalcoEventsGetter interface {
 type FalcoEventInterface interface {
 	Create(ctx context.Context, falcoEvent *v1alpha1.FalcoEvent, opts v1.CreateOptions) (*v1alpha1.FalcoEvent, error)
 	Update(ctx context.Context, falcoEvent *v1alpha1.FalcoEvent, opts v1.UpdateOptions) (*v1alpha1.FalcoEvent, error)
+	UpdateStatus(ctx context.Context, falcoEvent *v1alpha1.FalcoEvent, opts v1.UpdateOptions) (*v1alpha1.FalcoEvent, error)
 	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
 	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
 	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.FalcoEvent, error)
@@ -128,6 +129,21 @@ func (c *falcoEvents) Update(ctx context.Context, falcoEvent *v1alpha1.FalcoEven
 	return
 }
 
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *falcoEvents) UpdateStatus(ctx context.Context, falcoEvent *v1alpha1.FalcoEvent, opts v1.UpdateOptions) (result *v1alpha1.FalcoEvent, err error) {
+	result = &v1alpha1.FalcoEvent{}
+	err = c.client.Put().
+		Resource("falcoevents").
+		Name(falcoEvent.Name).
+		SubResource("status").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(falcoEvent).
+		Do(ctx).
+		Into(result)
+	return
+}
+
 // Delete takes name of the falcoEvent and deletes it. Returns an error if one occurs.
 func (c *falcoEvents) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
 	return c.client.Delete().
@@ -24,6 +24,7 @@ import (
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 	cache "k8s.io/client-go/tools/cache"
 	v1alpha1 "kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
+	v1beta1 "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
 )
 
 // GenericInformer is type of SharedIndexInformer which will locate and delegate to other
@@ -56,6 +57,12 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	case v1alpha1.SchemeGroupVersion.WithResource("falcoevents"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Falco().V1alpha1().FalcoEvents().Informer()}, nil
 
+		// Group=falco.appscode.com, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithResource("falcoevents"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Falco().V1beta1().FalcoEvents().Informer()}, nil
+	case v1beta1.SchemeGroupVersion.WithResource("falcoretentionpolicies"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Falco().V1beta1().FalcoRetentionPolicies().Informer()}, nil
+
 	}
 
 	return nil, fmt.Errorf("no informer found for %v", resource)
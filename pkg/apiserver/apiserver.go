@@ -18,18 +18,38 @@ package apiserver
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"net/http"
+	"os"
+	"strings"
 	"time"
 
 	"kubeops.dev/falco-ui-server/apis/falco"
 	"kubeops.dev/falco-ui-server/apis/falco/install"
 	api "kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
+	apiv1beta1 "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/archive"
+	"kubeops.dev/falco-ui-server/pkg/attack"
 	"kubeops.dev/falco-ui-server/pkg/cleaner"
+	"kubeops.dev/falco-ui-server/pkg/eventstore"
+	"kubeops.dev/falco-ui-server/pkg/export"
 	"kubeops.dev/falco-ui-server/pkg/falcosidekick"
 	"kubeops.dev/falco-ui-server/pkg/falcosidekick/metricshandler"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/outputs"
+	"kubeops.dev/falco-ui-server/pkg/index"
+	"kubeops.dev/falco-ui-server/pkg/listener"
+	"kubeops.dev/falco-ui-server/pkg/policyreport"
+	"kubeops.dev/falco-ui-server/pkg/quota"
+	famstorage "kubeops.dev/falco-ui-server/pkg/registry/falco/falcoattackmatrix"
 	festorage "kubeops.dev/falco-ui-server/pkg/registry/falco/falcoevent"
+	frpstorage "kubeops.dev/falco-ui-server/pkg/registry/falco/falcoretentionpolicy"
+	"kubeops.dev/falco-ui-server/pkg/retention"
 
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	authenticationv1 "k8s.io/api/authentication/v1"
 	core "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -37,6 +57,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apiserver/pkg/registry/generic"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	"k8s.io/client-go/informers"
@@ -47,8 +68,10 @@ import (
 	cu "kmodules.xyz/client-go/client"
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 )
 
@@ -64,6 +87,7 @@ func init() {
 	install.Install(Scheme)
 	utilruntime.Must(clientgoscheme.AddToScheme(Scheme))
 	utilruntime.Must(api.AddToScheme(Scheme))
+	utilruntime.Must(apiv1beta1.AddToScheme(Scheme))
 
 	// we need to add the options to empty v1
 	// TODO fix the server code to avoid this
@@ -88,8 +112,41 @@ type ExtraConfig struct {
 	KubeInformerFactory informers.SharedInformerFactory
 	ResyncPeriod        time.Duration
 	EventTTLPeriod      time.Duration
+	RetentionPolicy     *retention.Policy
+	Capacity            cleaner.Capacity
+	CleanerInterval     time.Duration
+	CleanerPageSize     int64
+	CleanerWorkers      int
+	CleanerDryRun       bool
+	IngestUsers         []string
+	TableColumns        []festorage.OutputFieldColumn
+	StorageBackend      string
+	SegmentDir          string
+	SegmentDuration     time.Duration
+	PayloadLimits       falcosidekick.Limits
+	Quota               quota.Config
+	RecorderMinPriority string
+	// UIURL is the base URL of the UI the outputs link the FalcoEvents to.
+	UIURL string
+	// ArchiveSink, if set, receives expired FalcoEvents before the cleaner deletes them.
+	ArchiveSink   archive.Sink
+	ArchivePrefix string
+	// LeaderElection runs the cleaner on a single replica.
+	LeaderElection          bool
+	LeaderElectionNamespace string
+	// MetricsListener serves the metrics, quota, export and summary handlers, and the ingest
+	// handler unless IngestListener is enabled.
+	MetricsListener listener.Options
+	IngestListener  listener.Options
 }
 
+const (
+	// StorageBackendEtcd stores FalcoEvents in etcd.
+	StorageBackendEtcd = "etcd"
+	// StorageBackendSegment stores FalcoEvents in time partitioned segment files on local disk.
+	StorageBackendSegment = "segment"
+)
+
 // Config defines the config for the apiserver
 type Config struct {
 	GenericConfig *genericapiserver.RecommendedConfig
@@ -133,20 +190,26 @@ func (c completedConfig) New(ctx context.Context) (*FalcoUIServer, error) {
 	setupLog := log.Log.WithName("setup")
 
 	cfg := c.ExtraConfig.ClientConfig
+	// the handlers are served by the dedicated listeners, not by the manager
 	metricsHandlers := map[string]http.Handler{}
+	ingestHandlers := map[string]http.Handler{}
+	metricsReady := map[string]healthz.Checker{}
 	mgr, err := manager.New(cfg, manager.Options{
 		Scheme: Scheme,
 		Metrics: metricsserver.Options{
-			BindAddress:   "",
-			ExtraHandlers: metricsHandlers,
+			BindAddress: "0",
 		},
-		HealthProbeBindAddress: "",
-		LeaderElection:         false,
-		LeaderElectionID:       "5b87adeb.falco.appscode.com",
+		HealthProbeBindAddress:        "",
+		LeaderElection:                c.ExtraConfig.LeaderElection,
+		LeaderElectionNamespace:       c.ExtraConfig.LeaderElectionNamespace,
+		LeaderElectionID:              "5b87adeb.falco.appscode.com",
+		LeaderElectionReleaseOnCancel: true,
 		Client: client.Options{
 			Cache: &client.CacheOptions{
 				DisableFor: []client.Object{
 					&api.FalcoEvent{},
+					&apiv1beta1.FalcoEvent{},
+					&apiv1beta1.FalcoRetentionPolicy{},
 				},
 			},
 		},
@@ -165,8 +228,42 @@ func (c completedConfig) New(ctx context.Context) (*FalcoUIServer, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to start manager, reason: %v", err)
 	}
-	metricsHandlers["/falcoevents"] = falcosidekick.Handler(mgr.GetClient())
-	metricsHandlers["/falcometrics"] = metricshandler.Handler(mgr.GetClient())
+	var recorder *falcosidekick.Recorder
+	if c.ExtraConfig.RecorderMinPriority != "" {
+		recorder, err = falcosidekick.NewRecorder(mgr.GetEventRecorderFor("falco-ui-server"), mgr.GetAPIReader(), c.ExtraConfig.RecorderMinPriority)
+		if err != nil {
+			return nil, err
+		}
+	}
+	outs, err := outputs.New(falcosidekick.Config(), outputs.Options{UIURL: c.ExtraConfig.UIURL})
+	if err != nil {
+		return nil, err
+	}
+	var dispatcher *outputs.Dispatcher
+	if len(outs) > 0 {
+		dispatcher = outputs.NewDispatcher(outs...)
+		if err := mgr.Add(dispatcher); err != nil {
+			return nil, err
+		}
+	}
+	ingestHandlers["/falcoevents"] = falcosidekick.Handler(mgr.GetClient(), c.ExtraConfig.PayloadLimits, recorder, dispatcher)
+	metricsHandlers["/metrics"] = promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{ErrorHandling: promhttp.HTTPErrorOnError, EnableOpenMetrics: true})
+	metricsHandlers["/falcometrics"] = metricshandler.Handler(metrics.Registry)
+	// the delivery attempts of the outputs, as published by falcosidekick
+	if c.ExtraConfig.MetricsListener.Authorize {
+		metricsHandlers["/debug/vars"] = eventsHandler(outputs.StatsHandler())
+	} else {
+		setupLog.Info("Output statistics are disabled, they require --metrics-authorization")
+	}
+	informersSynced := func(req *http.Request) error {
+		ctx, cancel := context.WithTimeout(req.Context(), time.Second)
+		defer cancel()
+		if !mgr.GetCache().WaitForCacheSync(ctx) {
+			return fmt.Errorf("informers are not synced")
+		}
+		return nil
+	}
+	metricsReady["informers"] = informersSynced
 
 	setupLog.Info("setup done!")
 
@@ -178,19 +275,234 @@ func (c completedConfig) New(ctx context.Context) (*FalcoUIServer, error) {
 		apiGroupInfo := genericapiserver.NewDefaultAPIGroupInfo(falco.GroupName, Scheme, metav1.ParameterCodec, Codecs)
 
 		v1alpha1storage := map[string]rest.Storage{}
+		v1beta1storage := map[string]rest.Storage{}
 		{
-			storage, err := festorage.NewStorage(Scheme, c.GenericConfig.RESTOptionsGetter)
+			ingestUsers := c.ExtraConfig.IngestUsers
+			if len(ingestUsers) == 0 {
+				username, err := selfUsername(ctx, c.ExtraConfig.KubeClient, c.ExtraConfig.ClientConfig)
+				if err != nil {
+					return nil, fmt.Errorf("failed to detect ingest user, reason: %v", err)
+				}
+				ingestUsers = []string{username}
+			}
+			optsGetter := c.GenericConfig.RESTOptionsGetter
+			if c.ExtraConfig.StorageBackend == StorageBackendSegment {
+				optsGetter = eventstore.Config{
+					Dir:             c.ExtraConfig.SegmentDir,
+					SegmentDuration: c.ExtraConfig.SegmentDuration,
+					Retention:       c.ExtraConfig.EventTTLPeriod,
+					Codec:           Codecs.LegacyCodec(apiv1beta1.SchemeGroupVersion),
+					TimeFunc:        festorage.GetEventTime,
+					LabelIndexes:    []string{falco.LabelNamespaceName},
+					FieldIndexes:    []string{"spec.rule", "spec.priority"},
+				}
+			}
+			storage, err := festorage.NewStorage(Scheme, optsGetter, festorage.Config{
+				IngestUsers: ingestUsers,
+				Columns:     c.ExtraConfig.TableColumns,
+			})
 			if err != nil {
 				return nil, err
 			}
-			v1alpha1storage[api.ResourceFalcoEvents] = storage
+			if c.ExtraConfig.Quota.Enabled() {
+				cfg := c.ExtraConfig.Quota
+				cfg.PerReplica = c.ExtraConfig.StorageBackend == StorageBackendSegment
+				enforcer := quota.NewEnforcer(cfg, storage.Controller, mgr.Elected())
+				if err := mgr.Add(enforcer); err != nil {
+					return nil, err
+				}
+				// the usage names the namespaces and nodes holding events, so it is protected like the export
+				if c.ExtraConfig.MetricsListener.Authorize {
+					metricsHandlers["/falcoquotas"] = eventsHandler(enforcer.Handler())
+				} else {
+					setupLog.Info("FalcoEvent quota usage is disabled, it requires --metrics-authorization")
+				}
+			}
+			// the export holds the full events, so it is only served to the users allowed to list them
+			if c.ExtraConfig.MetricsListener.Authorize {
+				metricsHandlers["/falcoexport"] = eventsHandler(export.Handler(storage.Controller))
+			} else {
+				setupLog.Info("FalcoEvent export is disabled, it requires --metrics-authorization")
+			}
+
+			idx := index.New(storage.Controller, index.DefaultBucket)
+			if err := mgr.Add(idx); err != nil {
+				return nil, err
+			}
+			// the stored events are reported from the index, so a scrape does not list them
+			if err := metrics.Registry.Register(idx); err != nil {
+				return nil, err
+			}
+			// the summary names the namespaces, nodes and workloads of the events, so it is protected like the export
+			if c.ExtraConfig.MetricsListener.Authorize {
+				metricsHandlers["/falcosummary"] = eventsHandler(idx.Handler())
+			} else {
+				setupLog.Info("FalcoEvent summary is disabled, it requires --metrics-authorization")
+			}
+			if err := metrics.Registry.Register(attack.NewCollector(idx)); err != nil {
+				return nil, err
+			}
+			v1beta1storage[apiv1beta1.ResourceFalcoAttackMatrices] = famstorage.NewREST(idx)
+			metricsReady["index"] = func(*http.Request) error {
+				if !idx.Synced() {
+					return fmt.Errorf("FalcoEvent index is not synced")
+				}
+				return nil
+			}
+
+			v1alpha1storage[api.ResourceFalcoEvents] = storage.Controller
+			v1alpha1storage[api.ResourceFalcoEvents+"/status"] = storage.Status
+
+			// both versions are served from the same storage
+			v1beta1storage[apiv1beta1.ResourceFalcoEvents] = storage.Controller
+			v1beta1storage[apiv1beta1.ResourceFalcoEvents+"/status"] = storage.Status
+		}
+		// the policies are evaluated by the cleaner, which only runs with the etcd backend
+		if c.ExtraConfig.StorageBackend != StorageBackendSegment {
+			// FalcoRetentionPolicies are only served by v1beta1
+			storage, err := frpstorage.NewStorage(Scheme, storageVersion{
+				RESTOptionsGetter: c.GenericConfig.RESTOptionsGetter,
+				codec:             Codecs.LegacyCodec(apiv1beta1.SchemeGroupVersion),
+			})
+			if err != nil {
+				return nil, err
+			}
+			v1beta1storage[apiv1beta1.ResourceFalcoRetentionPolicies] = storage.Policy
+			v1beta1storage[apiv1beta1.ResourceFalcoRetentionPolicies+"/status"] = storage.Status
 		}
 		apiGroupInfo.VersionedResourcesStorageMap["v1alpha1"] = v1alpha1storage
+		apiGroupInfo.VersionedResourcesStorageMap["v1beta1"] = v1beta1storage
 
 		if err := s.GenericAPIServer.InstallAPIGroup(&apiGroupInfo); err != nil {
 			return nil, err
 		}
 	}
-	go cleaner.StartCleaner(mgr.GetClient(), c.ExtraConfig.EventTTLPeriod)
+	// the segment store drops expired events itself
+	if c.ExtraConfig.StorageBackend != StorageBackendSegment {
+		opts := cleaner.Options{
+			Policy:   c.ExtraConfig.RetentionPolicy,
+			Capacity: c.ExtraConfig.Capacity,
+			Interval: c.ExtraConfig.CleanerInterval,
+			PageSize: c.ExtraConfig.CleanerPageSize,
+			Workers:  c.ExtraConfig.CleanerWorkers,
+			DryRun:   c.ExtraConfig.CleanerDryRun,
+		}
+		if c.ExtraConfig.ArchiveSink != nil {
+			opts.Archiver = archive.NewArchiver(c.ExtraConfig.ArchiveSink, c.ExtraConfig.ArchivePrefix)
+		}
+		if err := mgr.Add(cleaner.New(mgr.GetClient(), opts)); err != nil {
+			return nil, err
+		}
+	}
+	if cfg := falcosidekick.Config(); cfg != nil && cfg.PolicyReport.Enabled {
+		if err := mgr.Add(policyreport.New(mgr.GetClient(), mgr.GetAPIReader(), cfg.PolicyReport)); err != nil {
+			return nil, err
+		}
+	}
+
+	// the ingest handler is served on the metrics listener, unless it has its own
+	if c.ExtraConfig.IngestListener.Enabled() {
+		l, err := listener.New(c.ExtraConfig.IngestListener, c.ExtraConfig.KubeClient, ingestHandlers, map[string]healthz.Checker{"informers": informersSynced})
+		if err != nil {
+			return nil, err
+		}
+		if err := mgr.Add(l); err != nil {
+			return nil, err
+		}
+	} else {
+		maps.Copy(metricsHandlers, ingestHandlers)
+	}
+	if c.ExtraConfig.MetricsListener.Enabled() {
+		l, err := listener.New(c.ExtraConfig.MetricsListener, c.ExtraConfig.KubeClient, metricsHandlers, metricsReady)
+		if err != nil {
+			return nil, err
+		}
+		if err := mgr.Add(l); err != nil {
+			return nil, err
+		}
+	}
 	return s, nil
 }
+
+// storageVersion stores the objects of the resources in the version of its codec, instead of
+// the default storage version.
+type storageVersion struct {
+	generic.RESTOptionsGetter
+	codec runtime.Codec
+}
+
+func (g storageVersion) GetRESTOptions(resource schema.GroupResource, example runtime.Object) (generic.RESTOptions, error) {
+	opts, err := g.RESTOptionsGetter.GetRESTOptions(resource, example)
+	if err != nil {
+		return opts, err
+	}
+	cfg := *opts.StorageConfig
+	cfg.Codec = g.codec
+	opts.StorageConfig = &cfg
+	return opts, nil
+}
+
+// eventsHandler serves FalcoEvent data on a listener, for the users allowed to list FalcoEvents.
+func eventsHandler(h http.Handler) listener.ResourceHandler {
+	return listener.ResourceHandler{
+		Handler:  h,
+		Group:    apiv1beta1.SchemeGroupVersion.Group,
+		Resource: apiv1beta1.ResourceFalcoEvents,
+		Verb:     "list",
+	}
+}
+
+// selfUsername returns the username the api server uses to talk to the kube-apiserver.
+// FalcoEvents written by the ingest path are forwarded through the kube-apiserver under
+// this identity. SelfSubjectReviews are served since Kubernetes 1.28, on older clusters
+// the username is taken from the client config.
+func selfUsername(ctx context.Context, kc kubernetes.Interface, cfg *restclient.Config) (string, error) {
+	review, err := kc.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{})
+	if err == nil {
+		return review.Status.UserInfo.Username, nil
+	}
+	if username := configUsername(cfg); username != "" {
+		return username, nil
+	}
+	return "", fmt.Errorf("%v, set --ingest-users", err)
+}
+
+// configUsername returns the basic auth username or the service account of the bearer token
+// of a client config, or "" if neither is known.
+func configUsername(cfg *restclient.Config) string {
+	if cfg == nil {
+		return ""
+	}
+	if cfg.Username != "" {
+		return cfg.Username
+	}
+	token := cfg.BearerToken
+	if token == "" && cfg.BearerTokenFile != "" {
+		data, err := os.ReadFile(cfg.BearerTokenFile)
+		if err != nil {
+			return ""
+		}
+		token = strings.TrimSpace(string(data))
+	}
+	return serviceAccountUsername(token)
+}
+
+// serviceAccountUsername returns the subject of a service account token. The token is not
+// verified, it is the one this server authenticates with.
+func serviceAccountUsername(token string) string {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if err := json.Unmarshal(data, &claims); err != nil || !strings.HasPrefix(claims.Subject, "system:serviceaccount:") {
+		return ""
+	}
+	return claims.Subject
+}
@@ -0,0 +1,77 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apiserver
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	restclient "k8s.io/client-go/rest"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestSelfUsername(t *testing.T) {
+	claims := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"system:serviceaccount:falco:falco-ui-server"}`))
+	token := "header." + claims + ".signature"
+
+	reviewed := fake.NewSimpleClientset()
+	reviewed.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, &authenticationv1.SelfSubjectReview{
+			Status: authenticationv1.SelfSubjectReviewStatus{UserInfo: authenticationv1.UserInfo{Username: "reviewed"}},
+		}, nil
+	})
+	// clusters older than 1.28 do not serve SelfSubjectReviews
+	unserved := fake.NewSimpleClientset()
+	unserved.PrependReactor("create", "selfsubjectreviews", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("the server could not find the requested resource")
+	})
+
+	cases := []struct {
+		name    string
+		client  *fake.Clientset
+		cfg     *restclient.Config
+		want    string
+		wantErr bool
+	}{
+		{name: "review", client: reviewed, cfg: &restclient.Config{BearerToken: token}, want: "reviewed"},
+		{name: "service account token", client: unserved, cfg: &restclient.Config{BearerToken: token}, want: "system:serviceaccount:falco:falco-ui-server"},
+		{name: "basic auth", client: unserved, cfg: &restclient.Config{Username: "admin"}, want: "admin"},
+		{name: "unknown", client: unserved, cfg: &restclient.Config{BearerToken: "opaque"}, wantErr: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := selfUsername(context.Background(), tc.client, tc.cfg)
+			if tc.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
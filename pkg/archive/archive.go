@@ -0,0 +1,267 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path"
+	"sort"
+	"strconv"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	eventsPrefix    = "events"
+	manifestsPrefix = "manifests"
+	partitionFormat = "2006/01/02/15"
+)
+
+// Manifest records the objects written by one archive run.
+type Manifest struct {
+	ArchivedAt time.Time        `json:"archivedAt"`
+	Objects    []ManifestObject `json:"objects"`
+}
+
+// ManifestObject is a gzip compressed NDJSON file of FalcoEvents of one hour.
+type ManifestObject struct {
+	Key string `json:"key"`
+	// From and Until are the times of the first and last event in the object.
+	From   time.Time `json:"from"`
+	Until  time.Time `json:"until"`
+	Count  int       `json:"count"`
+	Size   int       `json:"size"`
+	SHA256 string    `json:"sha256"`
+}
+
+// Archiver writes FalcoEvents to a sink, partitioned by the hour of the event time.
+type Archiver struct {
+	sink   Sink
+	prefix string
+	now    func() time.Time
+}
+
+// NewArchiver returns an Archiver writing below prefix of the sink.
+func NewArchiver(sink Sink, prefix string) *Archiver {
+	return &Archiver{sink: sink, prefix: prefix, now: time.Now}
+}
+
+func (a *Archiver) key(parts ...string) string {
+	return path.Join(append([]string{a.prefix}, parts...)...)
+}
+
+// Archive writes the events and a manifest listing them. The events may only be deleted
+// once Archive returns without error.
+func (a *Archiver) Archive(ctx context.Context, events []api.FalcoEvent) (*Manifest, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	now := a.now().UTC()
+	run := strconv.FormatInt(now.UnixNano(), 10)
+
+	partitions := map[string][]*api.FalcoEvent{}
+	for i := range events {
+		p := events[i].Spec.Time.UTC().Format(partitionFormat)
+		partitions[p] = append(partitions[p], &events[i])
+	}
+	names := make([]string, 0, len(partitions))
+	for p := range partitions {
+		names = append(names, p)
+	}
+	sort.Strings(names)
+
+	m := &Manifest{ArchivedAt: now}
+	for _, p := range names {
+		obj, data, err := encode(partitions[p])
+		if err != nil {
+			return nil, err
+		}
+		obj.Key = a.key(eventsPrefix, p, run+".ndjson.gz")
+		if err := a.sink.Put(ctx, obj.Key, data); err != nil {
+			return nil, fmt.Errorf("failed to archive %s: %w", obj.Key, err)
+		}
+		m.Objects = append(m.Objects, *obj)
+	}
+
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := a.sink.Put(ctx, a.key(manifestsPrefix, run+".json"), data); err != nil {
+		return nil, fmt.Errorf("failed to write archive manifest: %w", err)
+	}
+	klog.InfoS("Archived FalcoEvents", "count", len(events), "objects", len(m.Objects))
+	return m, nil
+}
+
+func encode(events []*api.FalcoEvent) (*ManifestObject, []byte, error) {
+	sort.Slice(events, func(i, j int) bool {
+		return events[i].Spec.Time.Before(&events[j].Spec.Time)
+	})
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, fe := range events {
+		fe = fe.DeepCopy()
+		fe.APIVersion = api.SchemeGroupVersion.String()
+		fe.Kind = api.ResourceKindFalcoEvent
+		fe.ManagedFields = nil
+		if err := enc.Encode(fe); err != nil {
+			return nil, nil, err
+		}
+	}
+	if err := zw.Close(); err != nil {
+		return nil, nil, err
+	}
+	sum := sha256.Sum256(buf.Bytes())
+	return &ManifestObject{
+		From:   events[0].Spec.Time.UTC(),
+		Until:  events[len(events)-1].Spec.Time.UTC(),
+		Count:  len(events),
+		Size:   buf.Len(),
+		SHA256: hex.EncodeToString(sum[:]),
+	}, buf.Bytes(), nil
+}
+
+// Manifests returns all manifests below prefix of the sink, oldest first.
+func Manifests(ctx context.Context, sink Sink, prefix string) ([]Manifest, error) {
+	keys, err := sink.List(ctx, path.Join(prefix, manifestsPrefix)+"/")
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Manifest, 0, len(keys))
+	for _, k := range keys {
+		data, err := sink.Get(ctx, k)
+		if err != nil {
+			return nil, err
+		}
+		var m Manifest
+		if err := json.Unmarshal(data, &m); err != nil {
+			return nil, fmt.Errorf("invalid manifest %s: %w", k, err)
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+// Read calls fn for every archived FalcoEvent with an event time in [since, until).
+// A zero time leaves the window open on that side.
+func Read(ctx context.Context, sink Sink, prefix string, since, until time.Time, fn func(fe *api.FalcoEvent) error) error {
+	manifests, err := Manifests(ctx, sink, prefix)
+	if err != nil {
+		return err
+	}
+	inWindow := func(t time.Time) bool {
+		return (since.IsZero() || !t.Before(since)) && (until.IsZero() || t.Before(until))
+	}
+	for _, m := range manifests {
+		for _, obj := range m.Objects {
+			if (!since.IsZero() && obj.Until.Before(since)) || (!until.IsZero() && !obj.From.Before(until)) {
+				continue
+			}
+			data, err := sink.Get(ctx, obj.Key)
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", obj.Key, err)
+			}
+			if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != obj.SHA256 {
+				return fmt.Errorf("checksum mismatch of %s", obj.Key)
+			}
+			zr, err := gzip.NewReader(bytes.NewReader(data))
+			if err != nil {
+				return fmt.Errorf("failed to read %s: %w", obj.Key, err)
+			}
+			s := bufio.NewScanner(zr)
+			s.Buffer(make([]byte, 0, 64<<10), 16<<20)
+			for s.Scan() {
+				var fe api.FalcoEvent
+				if err := json.Unmarshal(s.Bytes(), &fe); err != nil {
+					return fmt.Errorf("failed to decode event in %s: %w", obj.Key, err)
+				}
+				if !inWindow(fe.Spec.Time.Time) {
+					continue
+				}
+				if err := fn(&fe); err != nil {
+					return err
+				}
+			}
+			if err := s.Err(); err != nil {
+				return fmt.Errorf("failed to read %s: %w", obj.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Restore re-creates the archived FalcoEvents of the window. Restored events are annotated
+// with the restore time, so the cleaner keeps them for another TTL and does not archive them
+// again. The archived status is written back through the status subresource, as it is cleared
+// on create. Events that still exist are skipped. It returns the number of restored and skipped events.
+func Restore(ctx context.Context, kc client.Client, sink Sink, prefix string, since, until time.Time) (int, int, error) {
+	restoredAt := time.Now().UTC().Format(time.RFC3339)
+	var restored, skipped int
+	err := Read(ctx, sink, prefix, since, until, func(fe *api.FalcoEvent) error {
+		fe.ObjectMeta = metav1.ObjectMeta{
+			Name:        fe.Name,
+			Labels:      fe.Labels,
+			Annotations: fe.Annotations,
+		}
+		if fe.Annotations == nil {
+			fe.Annotations = map[string]string{}
+		}
+		fe.Annotations[api.AnnotationRestoredAt] = restoredAt
+		status := fe.Status
+		err := kc.Create(ctx, fe)
+		if apierrors.IsAlreadyExists(err) {
+			skipped++
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to restore FalcoEvent %s: %w", fe.Name, err)
+		}
+		fe.Status = status
+		if err := kc.Status().Update(ctx, fe); err != nil {
+			return fmt.Errorf("failed to restore the status of FalcoEvent %s: %w", fe.Name, err)
+		}
+		restored++
+		return nil
+	})
+	return restored, skipped, err
+}
+
+// RestoredAt returns the time an event was restored from the archive.
+func RestoredAt(fe *api.FalcoEvent) (time.Time, bool) {
+	s, ok := fe.Annotations[api.AnnotationRestoredAt]
+	if !ok {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	return t, err == nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package archive
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/spf13/pflag"
+)
+
+// Options configures the archive sink. At most one of Dir and S3Endpoint may be set.
+type Options struct {
+	Dir    string
+	Prefix string
+
+	S3Endpoint        string
+	S3Bucket          string
+	S3Region          string
+	S3AccessKeyID     string
+	S3SecretAccessKey string
+}
+
+func NewOptions() *Options {
+	return &Options{
+		S3Region: "us-east-1",
+	}
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Dir, "archive-dir", o.Dir, "Directory, eg, a mounted PVC, to archive expired FalcoEvents to")
+	fs.StringVar(&o.Prefix, "archive-prefix", o.Prefix, "Key prefix of the archived objects")
+	fs.StringVar(&o.S3Endpoint, "archive-s3-endpoint", o.S3Endpoint, "URL of an S3 compatible object store to archive expired FalcoEvents to, eg, https://minio.example.com:9000")
+	fs.StringVar(&o.S3Bucket, "archive-s3-bucket", o.S3Bucket, "Bucket to archive expired FalcoEvents to")
+	fs.StringVar(&o.S3Region, "archive-s3-region", o.S3Region, "Region of the archive bucket")
+	fs.StringVar(&o.S3AccessKeyID, "archive-s3-access-key-id", o.S3AccessKeyID, "Access key id of the archive bucket. Defaults to the AWS_ACCESS_KEY_ID environment variable")
+	fs.StringVar(&o.S3SecretAccessKey, "archive-s3-secret-access-key", o.S3SecretAccessKey, "Secret access key of the archive bucket. Defaults to the AWS_SECRET_ACCESS_KEY environment variable")
+}
+
+// Enabled returns true if an archive sink is configured.
+func (o *Options) Enabled() bool {
+	return o.Dir != "" || o.S3Endpoint != ""
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if o.Dir != "" && o.S3Endpoint != "" {
+		errs = append(errs, fmt.Errorf("only one of --archive-dir and --archive-s3-endpoint can be set"))
+	}
+	if o.S3Endpoint != "" {
+		if u, err := url.Parse(o.S3Endpoint); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("--archive-s3-endpoint must be a http or https URL"))
+		}
+		if o.S3Bucket == "" {
+			errs = append(errs, fmt.Errorf("--archive-s3-bucket must be set"))
+		}
+	}
+	return errs
+}
+
+// NewSink returns the configured sink, or nil if archiving is disabled.
+func (o *Options) NewSink() Sink {
+	switch {
+	case o.Dir != "":
+		return DirSink{Dir: o.Dir}
+	case o.S3Endpoint != "":
+		s := &S3Sink{
+			Endpoint:        o.S3Endpoint,
+			Bucket:          o.S3Bucket,
+			Region:          o.S3Region,
+			AccessKeyID:     o.S3AccessKeyID,
+			SecretAccessKey: o.S3SecretAccessKey,
+		}
+		if s.AccessKeyID == "" {
+			s.AccessKeyID = os.Getenv("AWS_ACCESS_KEY_ID")
+		}
+		if s.SecretAccessKey == "" {
+			s.SecretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+		}
+		return s
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attack
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tactic is an ATT&CK tactic and the tag of the Falco rules of the tactic.
+type Tactic struct {
+	ID   string
+	Name string
+	Tag  string
+}
+
+// Technique is an ATT&CK technique or sub-technique and the IDs of its tactics.
+type Technique struct {
+	ID      string
+	Name    string
+	Tactics []string
+}
+
+// Entry is a cell of the matrix a rule is mapped to. The technique is empty for the tactics
+// a rule is tagged with but none of its techniques belong to.
+type Entry struct {
+	Tactic    string
+	Technique Technique
+}
+
+var (
+	techniqueTag = regexp.MustCompile(`^T\d{4}(\.\d{3})?$`)
+
+	tacticsByTag = func() map[string]Tactic {
+		out := make(map[string]Tactic, len(tactics))
+		for _, t := range tactics {
+			out[t.Tag] = t
+		}
+		return out
+	}()
+)
+
+// Tactics returns the ATT&CK tactics in the order of the matrix.
+func Tactics() []Tactic {
+	return slices.Clone(tactics)
+}
+
+// lookup returns the technique of the ID and whether its tactics are known. Sub-techniques
+// missing in the mapping take the tactics of their parent.
+func lookup(id string) (Technique, bool) {
+	if t, ok := techniques[id]; ok {
+		t.ID = id
+		return t, true
+	}
+	if parent, _, ok := strings.Cut(id, "."); ok {
+		if t, ok := techniques[parent]; ok {
+			return Technique{ID: id, Tactics: t.Tactics}, true
+		}
+	}
+	return Technique{ID: id}, false
+}
+
+// Map returns the cells of the matrix a rule with the tags is mapped to. A technique is placed
+// under the tactics the rule is tagged with among the tactics of the technique, or under all
+// the tactics of the technique if the rule is tagged with none of them. Techniques missing in
+// the mapping are placed under the tactics the rule is tagged with.
+func Map(tags []string) []Entry {
+	var tagged, ids []string
+	for _, tag := range tags {
+		if t, ok := tacticsByTag[strings.ToLower(tag)]; ok {
+			if !slices.Contains(tagged, t.ID) {
+				tagged = append(tagged, t.ID)
+			}
+		} else if id := strings.ToUpper(tag); techniqueTag.MatchString(id) && !slices.Contains(ids, id) {
+			ids = append(ids, id)
+		}
+	}
+
+	var out []Entry
+	covered := map[string]bool{}
+	for _, id := range ids {
+		t, known := lookup(id)
+		var in []string
+		for _, tactic := range t.Tactics {
+			if slices.Contains(tagged, tactic) {
+				in = append(in, tactic)
+			}
+		}
+		switch {
+		case len(in) > 0:
+		case known:
+			in = t.Tactics
+		default:
+			in = tagged
+		}
+		for _, tactic := range in {
+			out = append(out, Entry{Tactic: tactic, Technique: t})
+			covered[tactic] = true
+		}
+	}
+	for _, tactic := range tagged {
+		if !covered[tactic] {
+			out = append(out, Entry{Tactic: tactic})
+		}
+	}
+	return out
+}
+
+// DefaultWindows are the windows of the matrices listed.
+var DefaultWindows = []string{"1h", "24h", "7d"}
+
+// ParseWindow parses the name of a matrix as its window. It accepts Go durations and a number of days, eg, 7d.
+func ParseWindow(name string) (time.Duration, error) {
+	var d time.Duration
+	var err error
+	if days, ok := strings.CutSuffix(name, "d"); ok {
+		var n int64
+		n, err = strconv.ParseInt(days, 10, 32)
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		d, err = time.ParseDuration(name)
+	}
+	if err != nil || d <= 0 {
+		return 0, fmt.Errorf("invalid window %q, expected a positive duration like 1h, 24h or 7d", name)
+	}
+	return d, nil
+}
@@ -0,0 +1,153 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attack
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/pkg/index"
+)
+
+func TestMap(t *testing.T) {
+	for _, tc := range []struct {
+		tags []string
+		want []Entry
+	}{
+		{
+			tags: []string{"container", "shell", "mitre_execution", "T1059"},
+			want: []Entry{{Tactic: "TA0002", Technique: Technique{ID: "T1059", Name: "Command and Scripting Interpreter", Tactics: []string{"TA0002"}}}},
+		},
+		{
+			// the tagged tactic selects among the tactics of the technique
+			tags: []string{"mitre_privilege_escalation", "T1548.001"},
+			want: []Entry{{Tactic: "TA0004", Technique: Technique{ID: "T1548.001", Name: "Setuid and Setgid", Tactics: []string{"TA0004", "TA0005"}}}},
+		},
+		{
+			// unknown sub-techniques take the tactics of their parent
+			tags: []string{"t1059.999"},
+			want: []Entry{{Tactic: "TA0002", Technique: Technique{ID: "T1059.999", Tactics: []string{"TA0002"}}}},
+		},
+		{
+			// unknown techniques are placed under the tagged tactics, tactics without technique are kept
+			tags: []string{"mitre_discovery", "mitre_impact", "T9999", "T1496"},
+			want: []Entry{
+				{Tactic: "TA0007", Technique: Technique{ID: "T9999"}},
+				{Tactic: "TA0040", Technique: Technique{ID: "T9999"}},
+				{Tactic: "TA0040", Technique: Technique{ID: "T1496", Name: "Resource Hijacking", Tactics: []string{"TA0040"}}},
+			},
+		},
+		{
+			tags: []string{"mitre_lateral_movement"},
+			want: []Entry{{Tactic: "TA0008"}},
+		},
+		{
+			tags: []string{"host", "filesystem"},
+		},
+	} {
+		if got := Map(tc.tags); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Map(%v) = %+v, want %+v", tc.tags, got, tc.want)
+		}
+	}
+}
+
+func TestParseWindow(t *testing.T) {
+	for name, want := range map[string]time.Duration{
+		"30m": 30 * time.Minute,
+		"24h": 24 * time.Hour,
+		"7d":  7 * 24 * time.Hour,
+		"0h":  0,
+		"-1h": 0,
+		"xd":  0,
+		"":    0,
+	} {
+		got, err := ParseWindow(name)
+		if want == 0 && err == nil || want != 0 && got != want {
+			t.Errorf("ParseWindow(%q) = %v, %v, want %v", name, got, err, want)
+		}
+	}
+}
+
+type fakeIndex struct {
+	rows   []index.Row
+	tags   map[string][]string
+	latest map[string][]index.Event
+	since  time.Time
+}
+
+func (f *fakeIndex) Bucket() time.Duration { return time.Hour }
+
+func (f *fakeIndex) Query(q index.Query) []index.Row {
+	f.since = q.Since
+	return f.rows
+}
+
+func (f *fakeIndex) Tags() map[string][]string { return f.tags }
+
+func (f *fakeIndex) Latest(since, until time.Time, n int) map[string][]index.Event {
+	return f.latest
+}
+
+func TestBuild(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	t0 := now.Add(-time.Minute)
+	x := &fakeIndex{
+		rows: []index.Row{
+			{Group: index.Group{Rule: "Terminal shell", Namespace: "shop", Node: "node-1", Workload: "cart"}, Counts: index.Counts{Events: 3, Occurrences: 9}},
+			{Group: index.Group{Rule: "Terminal shell", Namespace: "shop", Node: "node-2", Workload: "cart"}, Counts: index.Counts{Events: 1, Occurrences: 1}},
+			{Group: index.Group{Rule: "Run shell untrusted", Node: "node-1"}, Counts: index.Counts{Events: 2, Occurrences: 2}},
+			{Group: index.Group{Rule: "Unknown", Node: "node-1"}, Counts: index.Counts{Events: 5, Occurrences: 5}},
+		},
+		tags: map[string][]string{
+			"Terminal shell":      {"mitre_execution", "T1059"},
+			"Run shell untrusted": {"mitre_execution", "T1059.004"},
+		},
+		latest: map[string][]index.Event{
+			"Terminal shell":      {{Name: "fe-1", UUID: "u1", Time: t0}},
+			"Run shell untrusted": {{Name: "fe-2", UUID: "u2", Time: t0.Add(time.Second)}},
+		},
+	}
+
+	m := Build(x, "24h", 24*time.Hour, now)
+	if want := now.Add(-24 * time.Hour).Truncate(time.Hour); !x.since.Equal(want) || !m.Status.Since.Time.Equal(want) {
+		t.Errorf("since = %v, status %v, want %v", x.since, m.Status.Since, want)
+	}
+	if m.Status.Events != 11 || m.Status.UnmappedEvents != 5 {
+		t.Errorf("events = %d, unmapped %d, want 11 and 5", m.Status.Events, m.Status.UnmappedEvents)
+	}
+	if len(m.Status.Tactics) != len(tactics) {
+		t.Fatalf("got %d tactics, want %d", len(m.Status.Tactics), len(tactics))
+	}
+	exec := m.Status.Tactics[3]
+	if exec.ID != "TA0002" || exec.Events != 6 || exec.Occurrences != 12 || len(exec.Techniques) != 2 {
+		t.Fatalf("unexpected execution tactic %+v", exec)
+	}
+	tech := exec.Techniques[0]
+	if tech.ID != "T1059" || tech.Events != 4 || !reflect.DeepEqual(tech.Rules, []string{"Terminal shell"}) {
+		t.Errorf("unexpected technique %+v", tech)
+	}
+	if len(tech.Workloads) != 1 || tech.Workloads[0].Name != "cart" || tech.Workloads[0].Events != 4 {
+		t.Errorf("workloads = %+v, want cart with 4 events", tech.Workloads)
+	}
+	if len(tech.LatestEvents) != 1 || tech.LatestEvents[0].Name != "fe-1" || tech.LatestEvents[0].UUID != "u1" {
+		t.Errorf("latest events = %+v, want fe-1", tech.LatestEvents)
+	}
+	if sub := exec.Techniques[1]; sub.ID != "T1059.004" || len(sub.Workloads) != 1 || sub.Workloads[0].Node != "node-1" {
+		t.Errorf("unexpected sub-technique %+v", sub)
+	}
+}
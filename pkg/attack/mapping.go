@@ -0,0 +1,128 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attack
+
+// The bundled mapping of the MITRE ATT&CK Enterprise matrix, restricted to the techniques
+// tagged by the Falco rules. Tags of techniques missing here are still reported, under the
+// tactics tagged by the rule and without a name.
+
+// tactics are the Enterprise tactics in the order of the matrix.
+var tactics = []Tactic{
+	{ID: "TA0043", Name: "Reconnaissance", Tag: "mitre_reconnaissance"},
+	{ID: "TA0042", Name: "Resource Development", Tag: "mitre_resource_development"},
+	{ID: "TA0001", Name: "Initial Access", Tag: "mitre_initial_access"},
+	{ID: "TA0002", Name: "Execution", Tag: "mitre_execution"},
+	{ID: "TA0003", Name: "Persistence", Tag: "mitre_persistence"},
+	{ID: "TA0004", Name: "Privilege Escalation", Tag: "mitre_privilege_escalation"},
+	{ID: "TA0005", Name: "Defense Evasion", Tag: "mitre_defense_evasion"},
+	{ID: "TA0006", Name: "Credential Access", Tag: "mitre_credential_access"},
+	{ID: "TA0007", Name: "Discovery", Tag: "mitre_discovery"},
+	{ID: "TA0008", Name: "Lateral Movement", Tag: "mitre_lateral_movement"},
+	{ID: "TA0009", Name: "Collection", Tag: "mitre_collection"},
+	{ID: "TA0011", Name: "Command and Control", Tag: "mitre_command_and_control"},
+	{ID: "TA0010", Name: "Exfiltration", Tag: "mitre_exfiltration"},
+	{ID: "TA0040", Name: "Impact", Tag: "mitre_impact"},
+}
+
+// techniques maps the technique IDs to their name and tactic IDs. Sub-techniques missing here
+// take the tactics of their parent technique.
+var techniques = map[string]Technique{
+	"T1003":     {Name: "OS Credential Dumping", Tactics: []string{"TA0006"}},
+	"T1003.008": {Name: "/etc/passwd and /etc/shadow", Tactics: []string{"TA0006"}},
+	"T1005":     {Name: "Data from Local System", Tactics: []string{"TA0009"}},
+	"T1014":     {Name: "Rootkit", Tactics: []string{"TA0005"}},
+	"T1016":     {Name: "System Network Configuration Discovery", Tactics: []string{"TA0007"}},
+	"T1018":     {Name: "Remote System Discovery", Tactics: []string{"TA0007"}},
+	"T1021":     {Name: "Remote Services", Tactics: []string{"TA0008"}},
+	"T1021.004": {Name: "SSH", Tactics: []string{"TA0008"}},
+	"T1027":     {Name: "Obfuscated Files or Information", Tactics: []string{"TA0005"}},
+	"T1036":     {Name: "Masquerading", Tactics: []string{"TA0005"}},
+	"T1036.005": {Name: "Match Legitimate Name or Location", Tactics: []string{"TA0005"}},
+	"T1040":     {Name: "Network Sniffing", Tactics: []string{"TA0006", "TA0007"}},
+	"T1041":     {Name: "Exfiltration Over C2 Channel", Tactics: []string{"TA0010"}},
+	"T1046":     {Name: "Network Service Discovery", Tactics: []string{"TA0007"}},
+	"T1048":     {Name: "Exfiltration Over Alternative Protocol", Tactics: []string{"TA0010"}},
+	"T1053":     {Name: "Scheduled Task/Job", Tactics: []string{"TA0002", "TA0003", "TA0004"}},
+	"T1053.003": {Name: "Cron", Tactics: []string{"TA0002", "TA0003", "TA0004"}},
+	"T1055":     {Name: "Process Injection", Tactics: []string{"TA0004", "TA0005"}},
+	"T1055.008": {Name: "Ptrace System Calls", Tactics: []string{"TA0004", "TA0005"}},
+	"T1057":     {Name: "Process Discovery", Tactics: []string{"TA0007"}},
+	"T1059":     {Name: "Command and Scripting Interpreter", Tactics: []string{"TA0002"}},
+	"T1059.004": {Name: "Unix Shell", Tactics: []string{"TA0002"}},
+	"T1068":     {Name: "Exploitation for Privilege Escalation", Tactics: []string{"TA0004"}},
+	"T1069":     {Name: "Permission Groups Discovery", Tactics: []string{"TA0007"}},
+	"T1070":     {Name: "Indicator Removal", Tactics: []string{"TA0005"}},
+	"T1070.002": {Name: "Clear Linux or Mac System Logs", Tactics: []string{"TA0005"}},
+	"T1070.003": {Name: "Clear Command History", Tactics: []string{"TA0005"}},
+	"T1070.004": {Name: "File Deletion", Tactics: []string{"TA0005"}},
+	"T1071":     {Name: "Application Layer Protocol", Tactics: []string{"TA0011"}},
+	"T1078":     {Name: "Valid Accounts", Tactics: []string{"TA0001", "TA0003", "TA0004", "TA0005"}},
+	"T1082":     {Name: "System Information Discovery", Tactics: []string{"TA0007"}},
+	"T1083":     {Name: "File and Directory Discovery", Tactics: []string{"TA0007"}},
+	"T1087":     {Name: "Account Discovery", Tactics: []string{"TA0007"}},
+	"T1090":     {Name: "Proxy", Tactics: []string{"TA0011"}},
+	"T1095":     {Name: "Non-Application Layer Protocol", Tactics: []string{"TA0011"}},
+	"T1098":     {Name: "Account Manipulation", Tactics: []string{"TA0003", "TA0004"}},
+	"T1098.004": {Name: "SSH Authorized Keys", Tactics: []string{"TA0003", "TA0004"}},
+	"T1105":     {Name: "Ingress Tool Transfer", Tactics: []string{"TA0011"}},
+	"T1106":     {Name: "Native API", Tactics: []string{"TA0002"}},
+	"T1110":     {Name: "Brute Force", Tactics: []string{"TA0006"}},
+	"T1133":     {Name: "External Remote Services", Tactics: []string{"TA0001", "TA0003"}},
+	"T1136":     {Name: "Create Account", Tactics: []string{"TA0003"}},
+	"T1190":     {Name: "Exploit Public-Facing Application", Tactics: []string{"TA0001"}},
+	"T1203":     {Name: "Exploitation for Client Execution", Tactics: []string{"TA0002"}},
+	"T1204":     {Name: "User Execution", Tactics: []string{"TA0002"}},
+	"T1210":     {Name: "Exploitation of Remote Services", Tactics: []string{"TA0008"}},
+	"T1219":     {Name: "Remote Access Software", Tactics: []string{"TA0011"}},
+	"T1222":     {Name: "File and Directory Permissions Modification", Tactics: []string{"TA0005"}},
+	"T1222.002": {Name: "Linux and Mac File and Directory Permissions Modification", Tactics: []string{"TA0005"}},
+	"T1485":     {Name: "Data Destruction", Tactics: []string{"TA0040"}},
+	"T1486":     {Name: "Data Encrypted for Impact", Tactics: []string{"TA0040"}},
+	"T1489":     {Name: "Service Stop", Tactics: []string{"TA0040"}},
+	"T1496":     {Name: "Resource Hijacking", Tactics: []string{"TA0040"}},
+	"T1499":     {Name: "Endpoint Denial of Service", Tactics: []string{"TA0040"}},
+	"T1525":     {Name: "Implant Internal Image", Tactics: []string{"TA0003"}},
+	"T1529":     {Name: "System Shutdown/Reboot", Tactics: []string{"TA0040"}},
+	"T1543":     {Name: "Create or Modify System Process", Tactics: []string{"TA0003", "TA0004"}},
+	"T1543.002": {Name: "Systemd Service", Tactics: []string{"TA0003", "TA0004"}},
+	"T1547":     {Name: "Boot or Logon Autostart Execution", Tactics: []string{"TA0003", "TA0004"}},
+	"T1547.006": {Name: "Kernel Modules and Extensions", Tactics: []string{"TA0003", "TA0004"}},
+	"T1548":     {Name: "Abuse Elevation Control Mechanism", Tactics: []string{"TA0004", "TA0005"}},
+	"T1548.001": {Name: "Setuid and Setgid", Tactics: []string{"TA0004", "TA0005"}},
+	"T1552":     {Name: "Unsecured Credentials", Tactics: []string{"TA0006"}},
+	"T1552.001": {Name: "Credentials In Files", Tactics: []string{"TA0006"}},
+	"T1552.005": {Name: "Cloud Instance Metadata API", Tactics: []string{"TA0006"}},
+	"T1552.007": {Name: "Container API", Tactics: []string{"TA0006"}},
+	"T1555":     {Name: "Credentials from Password Stores", Tactics: []string{"TA0006"}},
+	"T1556":     {Name: "Modify Authentication Process", Tactics: []string{"TA0003", "TA0005", "TA0006"}},
+	"T1557":     {Name: "Adversary-in-the-Middle", Tactics: []string{"TA0006", "TA0009"}},
+	"T1560":     {Name: "Archive Collected Data", Tactics: []string{"TA0009"}},
+	"T1562":     {Name: "Impair Defenses", Tactics: []string{"TA0005"}},
+	"T1562.001": {Name: "Disable or Modify Tools", Tactics: []string{"TA0005"}},
+	"T1564":     {Name: "Hide Artifacts", Tactics: []string{"TA0005"}},
+	"T1564.001": {Name: "Hidden Files and Directories", Tactics: []string{"TA0005"}},
+	"T1565":     {Name: "Data Manipulation", Tactics: []string{"TA0040"}},
+	"T1572":     {Name: "Protocol Tunneling", Tactics: []string{"TA0011"}},
+	"T1574":     {Name: "Hijack Execution Flow", Tactics: []string{"TA0003", "TA0004", "TA0005"}},
+	"T1574.006": {Name: "Dynamic Linker Hijacking", Tactics: []string{"TA0003", "TA0004", "TA0005"}},
+	"T1609":     {Name: "Container Administration Command", Tactics: []string{"TA0002"}},
+	"T1610":     {Name: "Deploy Container", Tactics: []string{"TA0002", "TA0005"}},
+	"T1611":     {Name: "Escape to Host", Tactics: []string{"TA0004"}},
+	"T1612":     {Name: "Build Image on Host", Tactics: []string{"TA0005"}},
+	"T1613":     {Name: "Container and Resource Discovery", Tactics: []string{"TA0007"}},
+	"T1620":     {Name: "Reflective Code Loading", Tactics: []string{"TA0005"}},
+}
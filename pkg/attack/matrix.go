@@ -0,0 +1,192 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attack
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/pkg/index"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+const (
+	// latestEvents is the number of latest events reported per technique.
+	latestEvents = 5
+	// maxWorkloads is the number of workloads reported per technique.
+	maxWorkloads = 10
+)
+
+// Index is the FalcoEvent index the matrix is built from.
+type Index interface {
+	Bucket() time.Duration
+	Query(q index.Query) []index.Row
+	Tags() map[string][]string
+	Latest(since, until time.Time, n int) map[string][]index.Event
+}
+
+type workloadKey struct {
+	namespace string
+	name      string
+	node      string
+}
+
+type cellKey struct {
+	tactic    string
+	technique string
+}
+
+type cell struct {
+	tactic      string
+	info        Technique
+	events      int64
+	occurrences int64
+	rules       sets.Set[string]
+	workloads   map[workloadKey]int64
+}
+
+// Build returns the matrix of the events of the index in the window ending now. The window
+// starts at the beginning of its first time bucket. A zero window covers all the events.
+func Build(x Index, name string, window time.Duration, now time.Time) *api.FalcoAttackMatrix {
+	return build(x, name, window, now, latestEvents)
+}
+
+// build returns the matrix with the n latest events per technique.
+func build(x Index, name string, window time.Duration, now time.Time, n int) *api.FalcoAttackMatrix {
+	var since time.Time
+	if window > 0 {
+		since = now.Add(-window).Truncate(x.Bucket())
+	}
+	out := &api.FalcoAttackMatrix{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       api.FalcoAttackMatrixSpec{Window: metav1.Duration{Duration: window}},
+		Status: api.FalcoAttackMatrixStatus{
+			Since: metav1.NewTime(since),
+			Until: metav1.NewTime(now),
+		},
+	}
+
+	tags := x.Tags()
+	entries := map[string][]Entry{}
+	sums := map[string]*api.AttackTactic{}
+	cells := map[cellKey]*cell{}
+	rows := x.Query(index.Query{By: []string{index.ByRule, index.ByNamespace, index.ByNode, index.ByWorkload}, Since: since})
+	for _, row := range rows {
+		out.Status.Events += int64(row.Events)
+		es, ok := entries[row.Rule]
+		if !ok {
+			es = Map(tags[row.Rule])
+			entries[row.Rule] = es
+		}
+		if len(es) == 0 {
+			out.Status.UnmappedEvents += int64(row.Events)
+			continue
+		}
+
+		counted := sets.New[string]()
+		for _, e := range es {
+			if !counted.Has(e.Tactic) {
+				counted.Insert(e.Tactic)
+				s, ok := sums[e.Tactic]
+				if !ok {
+					s = &api.AttackTactic{}
+					sums[e.Tactic] = s
+				}
+				s.Events += int64(row.Events)
+				s.Occurrences += row.Occurrences
+			}
+			if e.Technique.ID == "" {
+				continue
+			}
+			k := cellKey{tactic: e.Tactic, technique: e.Technique.ID}
+			c, ok := cells[k]
+			if !ok {
+				c = &cell{tactic: e.Tactic, info: e.Technique, rules: sets.New[string](), workloads: map[workloadKey]int64{}}
+				cells[k] = c
+			}
+			c.events += int64(row.Events)
+			c.occurrences += row.Occurrences
+			c.rules.Insert(row.Rule)
+			// the events of a workload are counted together across the nodes it runs on
+			wk := workloadKey{node: row.Node}
+			if row.Namespace != "" {
+				wk = workloadKey{namespace: row.Namespace, name: row.Workload}
+			}
+			c.workloads[wk] += int64(row.Events)
+		}
+	}
+
+	latest := x.Latest(since, time.Time{}, n)
+	for _, t := range tactics {
+		at := api.AttackTactic{ID: t.ID, Name: t.Name}
+		if s, ok := sums[t.ID]; ok {
+			at.Events, at.Occurrences = s.Events, s.Occurrences
+		}
+		for _, c := range cells {
+			if c.tactic == t.ID {
+				at.Techniques = append(at.Techniques, c.toAPI(latest, n))
+			}
+		}
+		slices.SortFunc(at.Techniques, func(a, b api.AttackTechnique) int {
+			return cmp.Or(cmp.Compare(b.Events, a.Events), cmp.Compare(a.ID, b.ID))
+		})
+		out.Status.Tactics = append(out.Status.Tactics, at)
+	}
+	return out
+}
+
+func (c *cell) toAPI(latest map[string][]index.Event, n int) api.AttackTechnique {
+	out := api.AttackTechnique{
+		ID:          c.info.ID,
+		Name:        c.info.Name,
+		Events:      c.events,
+		Occurrences: c.occurrences,
+		Rules:       sets.List(c.rules),
+	}
+
+	var events []index.Event
+	for _, rule := range out.Rules {
+		events = append(events, latest[rule]...)
+	}
+	slices.SortFunc(events, func(a, b index.Event) int {
+		return cmp.Or(b.Time.Compare(a.Time), cmp.Compare(a.Name, b.Name))
+	})
+	for _, e := range events[:min(len(events), n)] {
+		out.LatestEvents = append(out.LatestEvents, api.AttackEventReference{
+			Name: e.Name,
+			UUID: e.UUID,
+			Rule: e.Group.Rule,
+			Time: metav1.NewMicroTime(e.Time),
+		})
+	}
+
+	for k, n := range c.workloads {
+		out.Workloads = append(out.Workloads, api.AttackWorkload{Namespace: k.namespace, Name: k.name, Node: k.node, Events: n})
+	}
+	slices.SortFunc(out.Workloads, func(a, b api.AttackWorkload) int {
+		return cmp.Or(cmp.Compare(b.Events, a.Events), cmp.Compare(a.Namespace, b.Namespace),
+			cmp.Compare(a.Name, b.Name), cmp.Compare(a.Node, b.Node))
+	})
+	if len(out.Workloads) > maxWorkloads {
+		out.Workloads = out.Workloads[:maxWorkloads]
+	}
+	return out
+}
@@ -0,0 +1,70 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package attack
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricPrefix = "falco_appscode_com_"
+
+var (
+	tacticEventsDesc = prometheus.NewDesc(
+		metricPrefix+"attack_tactic_events",
+		"Number of stored FalcoEvents by MITRE ATT&CK tactic",
+		[]string{"tactic_id", "tactic"},
+		nil,
+	)
+
+	techniqueEventsDesc = prometheus.NewDesc(
+		metricPrefix+"attack_technique_events",
+		"Number of stored FalcoEvents by MITRE ATT&CK tactic and technique",
+		[]string{"tactic_id", "tactic", "technique_id", "technique"},
+		nil,
+	)
+)
+
+// Collector reports the stored events of the index by tactic and technique.
+type Collector struct {
+	x Index
+}
+
+func NewCollector(x Index) *Collector {
+	return &Collector{x: x}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- tacticEventsDesc
+	ch <- techniqueEventsDesc
+}
+
+// Collect implements prometheus.Collector. Every tactic is reported, so the coverage of the
+// matrix can be read from the tactics without events. The latest events are not looked up,
+// so a scrape costs O(groups).
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	m := build(c.x, "", 0, time.Now(), 0)
+	for _, t := range m.Status.Tactics {
+		ch <- prometheus.MustNewConstMetric(tacticEventsDesc, prometheus.GaugeValue, float64(t.Events), t.ID, t.Name)
+		for _, tt := range t.Techniques {
+			ch <- prometheus.MustNewConstMetric(techniqueEventsDesc, prometheus.GaugeValue, float64(tt.Events),
+				t.ID, t.Name, tt.ID, tt.Name)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleaner
+
+import (
+	"encoding/json"
+	"sort"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	ktypes "k8s.io/apimachinery/pkg/types"
+)
+
+// DefaultLowWatermark is the fraction of the capacity limits the cleaner evicts down to.
+const DefaultLowWatermark = 0.9
+
+// Capacity caps the stored FalcoEvents. Zero disables a limit. Once a limit is exceeded,
+// events are evicted until the usage is below LowWatermark times the limit, so the
+// cleaner does not evict a few events on every pass.
+type Capacity struct {
+	MaxEvents    int
+	MaxBytes     int64
+	LowWatermark float64
+}
+
+// Enabled returns true if any limit is set.
+func (c Capacity) Enabled() bool {
+	return c.MaxEvents > 0 || c.MaxBytes > 0
+}
+
+// eventSize approximates the size of a stored event by the size of its JSON encoding.
+func eventSize(ev *api.FalcoEvent) int64 {
+	data, err := json.Marshal(ev)
+	if err != nil {
+		return 0
+	}
+	return int64(len(data))
+}
+
+// entry is what the cleaner keeps of an unexpired event to enforce the capacity and the
+// MaxCount of its policy.
+type entry struct {
+	name     string
+	uid      ktypes.UID
+	priority types.PriorityType
+	time     time.Time
+	size     int64
+	restored bool
+	policy   *policyState
+}
+
+func newEntry(ev *api.FalcoEvent, restored bool) entry {
+	return entry{
+		name:     ev.Name,
+		uid:      ev.UID,
+		priority: types.Priority(string(ev.Spec.Priority)),
+		time:     ev.Spec.Time.Time,
+		size:     eventSize(ev),
+		restored: restored,
+	}
+}
+
+// evict returns the indexes of the entries to evict, lowest priority first and oldest first
+// among events of the same priority, and the count and size of the events that are kept.
+func (c Capacity) evict(entries []entry) ([]int, int, int64) {
+	var total int64
+	for _, e := range entries {
+		total += e.size
+	}
+	count := len(entries)
+	if !c.Enabled() ||
+		(c.MaxEvents <= 0 || count <= c.MaxEvents) && (c.MaxBytes <= 0 || total <= c.MaxBytes) {
+		return nil, count, total
+	}
+
+	low := c.LowWatermark
+	if low <= 0 || low > 1 {
+		low = DefaultLowWatermark
+	}
+	maxEvents, maxBytes := int(float64(c.MaxEvents)*low), int64(float64(c.MaxBytes)*low)
+
+	order := make([]int, len(entries))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool {
+		a, b := &entries[order[i]], &entries[order[j]]
+		if a.priority != b.priority {
+			return a.priority < b.priority
+		}
+		if !a.time.Equal(b.time) {
+			return a.time.Before(b.time)
+		}
+		return a.name < b.name
+	})
+
+	var victims []int
+	for _, i := range order {
+		if (c.MaxEvents <= 0 || count <= maxEvents) && (c.MaxBytes <= 0 || total <= maxBytes) {
+			break
+		}
+		victims = append(victims, i)
+		count--
+		total -= entries[i].size
+	}
+	return victims, count, total
+}
@@ -0,0 +1,73 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleaner
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCapacityEvict(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newEvent := func(name string, p api.Priority, d time.Duration) api.FalcoEvent {
+		return api.FalcoEvent{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec:       api.FalcoEventSpec{Priority: p, Time: metav1.NewMicroTime(t0.Add(d))},
+		}
+	}
+	events := []api.FalcoEvent{
+		newEvent("critical-old", api.PriorityCritical, 0),
+		newEvent("notice-new", api.PriorityNotice, 3*time.Hour),
+		newEvent("warning-old", api.PriorityWarning, time.Hour),
+		newEvent("notice-old", api.PriorityNotice, 2*time.Hour),
+		newEvent("warning-new", api.PriorityWarning, 4*time.Hour),
+	}
+	entries := make([]entry, len(events))
+	var size int64
+	for i := range events {
+		entries[i] = newEntry(&events[i], false)
+		size += entries[i].size
+	}
+
+	tests := []struct {
+		name      string
+		capacity  Capacity
+		want      []string
+		wantCount int
+	}{
+		{"unlimited", Capacity{}, nil, 5},
+		{"within limit", Capacity{MaxEvents: 5}, nil, 5},
+		{"down to low watermark", Capacity{MaxEvents: 4, LowWatermark: 0.5}, []string{"notice-old", "notice-new", "warning-old"}, 2},
+		{"default low watermark", Capacity{MaxEvents: 4}, []string{"notice-old", "notice-new"}, 3},
+		{"bytes", Capacity{MaxBytes: size - 1, LowWatermark: 1}, []string{"notice-old"}, 4},
+	}
+	for _, tt := range tests {
+		victims, count, _ := tt.capacity.evict(entries)
+		var names []string
+		for _, i := range victims {
+			names = append(names, events[i].Name)
+		}
+		if !reflect.DeepEqual(names, tt.want) || count != tt.wantCount {
+			t.Errorf("%s: evict() = %v, %d, want %v, %d", tt.name, names, count, tt.want, tt.wantCount)
+		}
+	}
+}
@@ -18,38 +18,344 @@ package cleaner
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"time"
 
-	api "kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
+	"kubeops.dev/falco-ui-server/apis/falco"
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/archive"
+	"kubeops.dev/falco-ui-server/pkg/retention"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	"golang.org/x/sync/errgroup"
+	core "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 )
 
-func StartCleaner(kc client.Client, ttl time.Duration) {
-	klog.Infoln("Starts the FalcoEvent cleaner")
-	for range time.Tick(30 * time.Minute) {
-		err := cleanerFunc(kc, ttl)
-		if err != nil {
-			klog.Errorf("Error occurred while cleaning Falco Events : %s \n", err.Error())
-		}
+const (
+	DefaultInterval = 30 * time.Minute
+	DefaultPageSize = 500
+	DefaultWorkers  = 10
+)
+
+// Options configures which FalcoEvents the cleaner deletes and how.
+type Options struct {
+	// Policy sets the TTL of the events.
+	Policy *retention.Policy
+	// Capacity caps the events kept after the expired events are deleted.
+	Capacity Capacity
+	// Archiver, if set, archives the events before they are deleted.
+	Archiver *archive.Archiver
+
+	// Interval is the time between two passes.
+	Interval time.Duration
+	// PageSize is the number of events listed at a time.
+	PageSize int64
+	// Workers is the number of concurrent deletes.
+	Workers int
+	// DryRun only logs the events that would be deleted.
+	DryRun bool
+}
+
+// Cleaner deletes the FalcoEvents expired according to the FalcoRetentionPolicies or the
+// retention policy, or exceeding the MaxCount of their FalcoRetentionPolicy or the capacity.
+// If an archiver is set, the events are archived first and only deleted once the archive is
+// written. It runs on the leader only.
+type Cleaner struct {
+	kc   client.Client
+	opts Options
+}
+
+var _ manager.LeaderElectionRunnable = &Cleaner{}
+
+func New(kc client.Client, opts Options) *Cleaner {
+	if opts.Interval <= 0 {
+		opts.Interval = DefaultInterval
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = DefaultPageSize
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = DefaultWorkers
 	}
+	return &Cleaner{kc: kc, opts: opts}
 }
 
-func cleanerFunc(kc client.Client, ttl time.Duration) error {
-	var evList api.FalcoEventList
-	err := kc.List(context.TODO(), &evList)
+func (c *Cleaner) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs a pass every interval until the context is done. It implements manager.Runnable.
+func (c *Cleaner) Start(ctx context.Context) error {
+	klog.InfoS("Starts the FalcoEvent cleaner", "interval", c.opts.Interval, "dryRun", c.opts.DryRun)
+	capacityLimitEvents.Set(float64(c.opts.Capacity.MaxEvents))
+	capacityLimitBytes.Set(float64(c.opts.Capacity.MaxBytes))
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := c.Run(ctx); err != nil {
+			klog.ErrorS(err, "failed to clean FalcoEvents")
+		}
+	}, c.opts.Interval)
+	return nil
+}
+
+// Run makes a single pass. It keeps going past failed archive and delete calls and returns
+// them aggregated.
+func (c *Cleaner) Run(ctx context.Context) error {
+	start := time.Now()
+	defer func() {
+		passDuration.Observe(time.Since(start).Seconds())
+	}()
+
+	policies, err := c.listPolicies(ctx)
+	if err != nil {
+		failures.WithLabelValues(stageList).Inc()
+		return err
+	}
+	nsLabels, err := c.namespaceLabels(ctx, policies)
 	if err != nil {
+		failures.WithLabelValues(stageList).Inc()
 		return err
 	}
-	for _, ev := range evList.Items {
-		if time.Since(ev.Spec.Time.Time) >= ttl {
-			err = kc.Delete(context.TODO(), &ev)
-			if err != nil && !errors.IsNotFound(err) {
-				return err
+
+	var errs []error
+	var kept []entry
+	count := 0
+	err = c.forEachPage(ctx, func(events []api.FalcoEvent) {
+		expired := map[*policyState][]api.FalcoEvent{}
+		for i := range events {
+			ev := &events[i]
+			ls := nsLabels[ev.Labels[falco.LabelNamespaceName]]
+			p := policies.match(ev, ls)
+			if p != nil {
+				p.matched++
+			}
+			ttl := p.ttl(c.opts.Policy.TTL(ev, ls))
+			// restored events are kept for another ttl
+			since := ev.Spec.Time.Time
+			restoredAt, restored := archive.RestoredAt(ev)
+			if restored {
+				since = restoredAt
+			}
+			if time.Since(since) >= ttl {
+				expired[p] = append(expired[p], *ev)
+				continue
+			}
+			count++
+			if c.opts.Capacity.Enabled() || p.limited() {
+				e := newEntry(ev, restored)
+				e.policy = p
+				kept = append(kept, e)
+			}
+		}
+		for p, batch := range expired {
+			errs = append(errs, c.remove(ctx, batch, ReasonExpired, p)...)
+		}
+	})
+	if err != nil {
+		failures.WithLabelValues(stageList).Inc()
+		return utilerrors.NewAggregate(append(errs, err))
+	}
+
+	if victims := overflow(kept); len(victims) > 0 {
+		errs = append(errs, c.removeEntries(ctx, kept, victims, ReasonMaxCount)...)
+		kept = without(kept, victims)
+		count -= len(victims)
+	}
+	if c.opts.Capacity.Enabled() {
+		victims, n, size := c.opts.Capacity.evict(kept)
+		errs = append(errs, c.removeEntries(ctx, kept, victims, ReasonCapacity)...)
+		count = n
+		storedBytes.Set(float64(size))
+	}
+	storedEvents.Set(float64(count))
+
+	if !c.opts.DryRun {
+		errs = append(errs, c.updateStatus(ctx, policies, start)...)
+	}
+	if len(errs) == 0 {
+		lastSuccess.SetToCurrentTime()
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+func (c *Cleaner) namespaceLabels(ctx context.Context, policies policySet) (map[string]labels.Set, error) {
+	if !c.opts.Policy.NeedsNamespaceLabels() && !policies.needsNamespaceLabels() {
+		return nil, nil
+	}
+	var nsList core.NamespaceList
+	if err := c.kc.List(ctx, &nsList); err != nil {
+		return nil, err
+	}
+	out := make(map[string]labels.Set, len(nsList.Items))
+	for _, ns := range nsList.Items {
+		out[ns.Name] = ns.Labels
+	}
+	return out, nil
+}
+
+func (c *Cleaner) forEachPage(ctx context.Context, fn func(events []api.FalcoEvent)) error {
+	opts := &client.ListOptions{Limit: c.opts.PageSize}
+	for {
+		var list api.FalcoEventList
+		if err := c.kc.List(ctx, &list, opts); err != nil {
+			return err
+		}
+		fn(list.Items)
+		if list.Continue == "" {
+			return nil
+		}
+		opts.Continue = list.Continue
+	}
+}
+
+// removeEntries removes the events of the victim entries. The events that have to be archived
+// are listed again, as only the size and age of the kept events are held in memory.
+func (c *Cleaner) removeEntries(ctx context.Context, entries []entry, victims []int, reason string) []error {
+	var errs []error
+	targets := map[*policyState][]target{}
+	toArchive := map[string]*policyState{}
+	for _, i := range victims {
+		e := &entries[i]
+		if c.archives(e.policy) {
+			toArchive[e.name] = e.policy
+			continue
+		}
+		targets[e.policy] = append(targets[e.policy], target{name: e.name, uid: e.uid})
+	}
+	for p, t := range targets {
+		errs = append(errs, c.delete(ctx, t, reason, p)...)
+	}
+	if len(toArchive) == 0 {
+		return errs
+	}
+
+	err := c.forEachPage(ctx, func(events []api.FalcoEvent) {
+		batches := map[*policyState][]api.FalcoEvent{}
+		for _, ev := range events {
+			if p, ok := toArchive[ev.Name]; ok {
+				batches[p] = append(batches[p], ev)
 			}
 		}
+		for p, batch := range batches {
+			errs = append(errs, c.remove(ctx, batch, reason, p)...)
+		}
+	})
+	if err != nil {
+		failures.WithLabelValues(stageList).Inc()
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+// without returns the entries except the victims. victims must be sorted.
+func without(entries []entry, victims []int) []entry {
+	out := make([]entry, 0, len(entries)-len(victims))
+	for i, e := range entries {
+		if len(victims) > 0 && victims[0] == i {
+			victims = victims[1:]
+			continue
+		}
+		out = append(out, e)
+	}
+	return out
+}
+
+// archives returns true if the events governed by the policy are archived before they are
+// deleted. Events governed by no policy are archived whenever an archiver is set.
+func (c *Cleaner) archives(p *policyState) bool {
+	return c.opts.Archiver != nil && !c.opts.DryRun && (p == nil || p.Spec.Archive)
+}
+
+// remove archives the events, unless they were restored from the archive or the policy
+// governing them does not ask for it, and deletes them. Events are not deleted if archiving fails.
+func (c *Cleaner) remove(ctx context.Context, events []api.FalcoEvent, reason string, p *policyState) []error {
+	if len(events) == 0 {
+		return nil
+	}
+	if c.archives(p) {
+		toArchive := make([]api.FalcoEvent, 0, len(events))
+		for _, ev := range events {
+			if _, ok := archive.RestoredAt(&ev); !ok {
+				toArchive = append(toArchive, ev)
+			}
+		}
+		if _, err := c.opts.Archiver.Archive(ctx, toArchive); err != nil {
+			failures.WithLabelValues(stageArchive).Inc()
+			return []error{err}
+		}
+	}
+
+	targets := make([]target, 0, len(events))
+	for _, ev := range events {
+		targets = append(targets, target{name: ev.Name, uid: ev.UID})
+	}
+	return c.delete(ctx, targets, reason, p)
+}
+
+type target struct {
+	name string
+	uid  ktypes.UID
+}
+
+// delete deletes the events with up to Workers concurrent calls and counts them as deleted by
+// the policy. The uid precondition keeps an event recreated in the meantime.
+func (c *Cleaner) delete(ctx context.Context, targets []target, reason string, p *policyState) []error {
+	if c.opts.DryRun {
+		for _, t := range targets {
+			klog.V(2).InfoS("Dry run, would delete FalcoEvent", "name", t.name, "reason", reason)
+		}
+		klog.InfoS("Dry run, would delete FalcoEvents", "count", len(targets), "reason", reason)
+		return nil
+	}
+
+	var (
+		mu      sync.Mutex
+		deleted int64
+		failed  int
+		lastErr error
+	)
+	var g errgroup.Group
+	g.SetLimit(c.opts.Workers)
+	for _, t := range targets {
+		g.Go(func() error {
+			ev := &api.FalcoEvent{ObjectMeta: metav1.ObjectMeta{Name: t.name}}
+			var opts []client.DeleteOption
+			if t.uid != "" {
+				opts = append(opts, client.Preconditions{UID: &t.uid})
+			}
+			err := c.kc.Delete(ctx, ev, opts...)
+			switch {
+			case err == nil:
+				deletedEvents.WithLabelValues(reason).Inc()
+				mu.Lock()
+				deleted++
+				mu.Unlock()
+			case apierrors.IsNotFound(err), apierrors.IsConflict(err):
+			default:
+				failures.WithLabelValues(stageDelete).Inc()
+				klog.V(2).InfoS("failed to delete FalcoEvent", "name", t.name, "err", err)
+				mu.Lock()
+				failed++
+				lastErr = err
+				mu.Unlock()
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+	if p != nil {
+		p.deleted += deleted
+	}
+	if failed > 0 {
+		return []error{fmt.Errorf("failed to delete %d of %d FalcoEvents, last error: %w", failed, len(targets), lastErr)}
 	}
 	return nil
 }
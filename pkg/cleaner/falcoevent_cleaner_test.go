@@ -0,0 +1,226 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleaner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/retention"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeClient serves FalcoEvents in pages and fails to delete the events in failDelete.
+type fakeClient struct {
+	client.Client
+
+	mu         sync.Mutex
+	events     map[string]api.FalcoEvent
+	policies   []api.FalcoRetentionPolicy
+	failDelete map[string]bool
+	lists      int
+}
+
+func (f *fakeClient) List(_ context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if l, ok := list.(*api.FalcoRetentionPolicyList); ok {
+		l.Items = append([]api.FalcoRetentionPolicy(nil), f.policies...)
+		return nil
+	}
+	f.lists++
+
+	o := &client.ListOptions{}
+	o.ApplyOptions(opts)
+	names := make([]string, 0, len(f.events))
+	for name := range f.events {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	// like etcd, continue after the last returned key, so deletes do not shift the pages
+	start := sort.SearchStrings(names, o.Continue)
+	if start < len(names) && names[start] == o.Continue {
+		start++
+	}
+	end := len(names)
+	if o.Limit > 0 {
+		end = min(start+int(o.Limit), len(names))
+	}
+	l := list.(*api.FalcoEventList)
+	l.Items = nil
+	l.Continue = ""
+	for _, name := range names[start:end] {
+		l.Items = append(l.Items, f.events[name])
+	}
+	if end < len(names) {
+		l.Continue = names[end-1]
+	}
+	return nil
+}
+
+func (f *fakeClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.failDelete[obj.GetName()] {
+		return errors.New("injected failure")
+	}
+	delete(f.events, obj.GetName())
+	return nil
+}
+
+func (f *fakeClient) Status() client.SubResourceWriter {
+	return fakeStatusWriter{fakeClient: f}
+}
+
+// fakeStatusWriter stores the status patches of FalcoRetentionPolicies.
+type fakeStatusWriter struct {
+	client.SubResourceWriter
+	*fakeClient
+}
+
+func (w fakeStatusWriter) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i := range w.policies {
+		if w.policies[i].Name == obj.GetName() {
+			w.policies[i].Status = obj.(*api.FalcoRetentionPolicy).Status
+		}
+	}
+	return nil
+}
+
+func TestCleanerRun(t *testing.T) {
+	now := time.Now()
+	newClient := func() *fakeClient {
+		kc := &fakeClient{events: map[string]api.FalcoEvent{}, failDelete: map[string]bool{}}
+		add := func(name string, p api.Priority, age time.Duration) {
+			kc.events[name] = api.FalcoEvent{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       api.FalcoEventSpec{Priority: p, Time: metav1.NewMicroTime(now.Add(-age))},
+			}
+		}
+		add("expired-1", api.PriorityWarning, 3*time.Hour)
+		add("expired-2", api.PriorityCritical, 3*time.Hour)
+		add("expired-3", api.PriorityNotice, 5*time.Hour)
+		add("kept-critical", api.PriorityCritical, time.Hour)
+		add("kept-notice", api.PriorityNotice, 30*time.Minute)
+		add("kept-warning", api.PriorityWarning, time.Hour)
+		return kc
+	}
+	remaining := func(kc *fakeClient) []string {
+		var names []string
+		for name := range kc.events {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+
+	t.Run("expired", func(t *testing.T) {
+		kc := newClient()
+		c := New(kc, Options{Policy: retention.NewPolicy(2 * time.Hour), PageSize: 2})
+		if err := c.Run(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+		if got := remaining(kc); len(got) != 3 || got[0] != "kept-critical" {
+			t.Errorf("remaining events %v", got)
+		}
+		if kc.lists != 3 {
+			t.Errorf("listed %d pages, want 3", kc.lists)
+		}
+	})
+
+	t.Run("capacity", func(t *testing.T) {
+		kc := newClient()
+		c := New(kc, Options{Policy: retention.NewPolicy(2 * time.Hour), Capacity: Capacity{MaxEvents: 2, LowWatermark: 1}})
+		if err := c.Run(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+		if got := remaining(kc); len(got) != 2 || got[0] != "kept-critical" || got[1] != "kept-warning" {
+			t.Errorf("remaining events %v", got)
+		}
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		kc := newClient()
+		c := New(kc, Options{Policy: retention.NewPolicy(2 * time.Hour), DryRun: true})
+		if err := c.Run(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+		if got := remaining(kc); len(got) != 6 {
+			t.Errorf("remaining events %v", got)
+		}
+	})
+
+	t.Run("policies", func(t *testing.T) {
+		kc := newClient()
+		maxCount := int64(1)
+		kc.policies = []api.FalcoRetentionPolicy{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "critical", Generation: 2},
+				Spec: api.FalcoRetentionPolicySpec{
+					Selector: api.FalcoEventSelector{Priorities: []api.Priority{api.PriorityCritical}},
+					TTL:      &metav1.Duration{Duration: 24 * time.Hour},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "notice"},
+				Spec: api.FalcoRetentionPolicySpec{
+					Precedence: 1,
+					Selector:   api.FalcoEventSelector{Priorities: []api.Priority{api.PriorityNotice}},
+					TTL:        &metav1.Duration{Duration: 24 * time.Hour},
+					MaxCount:   &maxCount,
+				},
+			},
+		}
+		c := New(kc, Options{Policy: retention.NewPolicy(2 * time.Hour)})
+		if err := c.Run(context.TODO()); err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"expired-2", "kept-critical", "kept-notice", "kept-warning"}
+		if got := remaining(kc); !slices.Equal(got, want) {
+			t.Errorf("remaining events %v, want %v", got, want)
+		}
+		critical, notice := kc.policies[0].Status, kc.policies[1].Status
+		if critical.ObservedGeneration != 2 || critical.MatchedEvents != 2 || critical.DeletedEvents != 0 {
+			t.Errorf("status of policy critical %+v", critical)
+		}
+		if notice.MatchedEvents != 2 || notice.DeletedEvents != 1 || notice.LastEvaluationTime == nil {
+			t.Errorf("status of policy notice %+v", notice)
+		}
+	})
+
+	t.Run("continues past errors", func(t *testing.T) {
+		kc := newClient()
+		kc.failDelete["expired-1"] = true
+		c := New(kc, Options{Policy: retention.NewPolicy(2 * time.Hour), PageSize: 2})
+		if err := c.Run(context.TODO()); err == nil {
+			t.Error("expected an error")
+		}
+		if got := remaining(kc); len(got) != 4 || got[0] != "expired-1" {
+			t.Errorf("remaining events %v", got)
+		}
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleaner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricPrefix = "falco_appscode_com_"
+
+// Reasons a FalcoEvent is deleted for.
+const (
+	ReasonExpired  = "expired"
+	ReasonCapacity = "capacity"
+	ReasonMaxCount = "max_count"
+)
+
+// Stages of a cleaner pass that can fail.
+const (
+	stageList    = "list"
+	stageArchive = "archive"
+	stageDelete  = "delete"
+	stageStatus  = "status"
+)
+
+var (
+	storedEvents = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "stored_events",
+		Help: "Number of stored FalcoEvents after the last cleaner pass",
+	})
+
+	storedBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "stored_events_bytes",
+		Help: "Approximate size in bytes of the stored FalcoEvents after the last cleaner pass, if a capacity limit is set",
+	})
+
+	capacityLimitEvents = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "capacity_limit_events",
+		Help: "Maximum number of stored FalcoEvents, zero if unlimited",
+	})
+
+	capacityLimitBytes = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "capacity_limit_bytes",
+		Help: "Maximum approximate size in bytes of the stored FalcoEvents, zero if unlimited",
+	})
+
+	deletedEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricPrefix + "cleaner_deleted_events_total",
+		Help: "Number of FalcoEvents deleted by the cleaner because they expired or exceeded the capacity or the max count of their policy",
+	}, []string{"reason"})
+
+	failures = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricPrefix + "cleaner_failures_total",
+		Help: "Number of failed list, archive, delete and policy status calls of the cleaner",
+	}, []string{"stage"})
+
+	passDuration = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    metricPrefix + "cleaner_pass_duration_seconds",
+		Help:    "Duration of the cleaner passes",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 14),
+	})
+
+	lastSuccess = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "cleaner_last_success_timestamp_seconds",
+		Help: "Time of the last cleaner pass without any failure",
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		storedEvents,
+		storedBytes,
+		capacityLimitEvents,
+		capacityLimitBytes,
+		deletedEvents,
+		failures,
+		passDuration,
+		lastSuccess,
+	)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cleaner
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco"
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// policyState is a FalcoRetentionPolicy and what a pass has done on its behalf.
+type policyState struct {
+	*api.FalcoRetentionPolicy
+
+	selector labels.Selector
+	matched  int64
+	deleted  int64
+}
+
+// policySet holds the FalcoRetentionPolicies in the order they are evaluated in.
+type policySet []*policyState
+
+// listPolicies returns the FalcoRetentionPolicies ordered by precedence, then name.
+// Policies with an invalid namespace selector are skipped.
+func (c *Cleaner) listPolicies(ctx context.Context) (policySet, error) {
+	var list api.FalcoRetentionPolicyList
+	if err := c.kc.List(ctx, &list); err != nil {
+		return nil, err
+	}
+	out := make(policySet, 0, len(list.Items))
+	for i := range list.Items {
+		p := &policyState{FalcoRetentionPolicy: &list.Items[i]}
+		if sel := p.Spec.Selector.NamespaceSelector; sel != nil {
+			s, err := metav1.LabelSelectorAsSelector(sel)
+			if err != nil {
+				klog.ErrorS(err, "skips FalcoRetentionPolicy with invalid namespace selector", "name", p.Name)
+				continue
+			}
+			p.selector = s
+		}
+		out = append(out, p)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Spec.Precedence != out[j].Spec.Precedence {
+			return out[i].Spec.Precedence < out[j].Spec.Precedence
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out, nil
+}
+
+// needsNamespaceLabels returns true if any policy selects namespaces by label.
+func (ps policySet) needsNamespaceLabels() bool {
+	for _, p := range ps {
+		if p.selector != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// match returns the policy governing the event, nil if none matches.
+func (ps policySet) match(fe *api.FalcoEvent, nsLabels labels.Set) *policyState {
+	for _, p := range ps {
+		if p.matches(fe, nsLabels) {
+			return p
+		}
+	}
+	return nil
+}
+
+func (p *policyState) matches(fe *api.FalcoEvent, nsLabels labels.Set) bool {
+	sel := &p.Spec.Selector
+	if len(sel.Rules) > 0 && !slices.Contains(sel.Rules, fe.Spec.Rule) {
+		return false
+	}
+	if len(sel.Priorities) > 0 && !slices.Contains(sel.Priorities, fe.Spec.Priority) {
+		return false
+	}
+	if len(sel.Sources) > 0 && !slices.ContainsFunc(sel.Sources, func(s string) bool {
+		return strings.EqualFold(s, fe.Spec.Source)
+	}) {
+		return false
+	}
+	if p.selector != nil {
+		if fe.Labels[falco.LabelNamespaceName] == "" || nsLabels == nil || !p.selector.Matches(nsLabels) {
+			return false
+		}
+	}
+	return true
+}
+
+// ttl returns the TTL set by the policy, or def if the policy is nil or sets none.
+func (p *policyState) ttl(def time.Duration) time.Duration {
+	if p == nil || p.Spec.TTL == nil {
+		return def
+	}
+	return p.Spec.TTL.Duration
+}
+
+// limited returns true if the policy caps the number of its events.
+func (p *policyState) limited() bool {
+	return p != nil && p.Spec.MaxCount != nil
+}
+
+// overflow returns the indexes of the entries exceeding the MaxCount of their policy,
+// keeping the newest events of each policy.
+func overflow(entries []entry) []int {
+	byPolicy := map[*policyState][]int{}
+	for i, e := range entries {
+		if e.policy.limited() {
+			byPolicy[e.policy] = append(byPolicy[e.policy], i)
+		}
+	}
+	var victims []int
+	for p, idx := range byPolicy {
+		maxCount := int(max(*p.Spec.MaxCount, 0))
+		if len(idx) <= maxCount {
+			continue
+		}
+		sort.Slice(idx, func(i, j int) bool {
+			a, b := &entries[idx[i]], &entries[idx[j]]
+			if !a.time.Equal(b.time) {
+				return a.time.After(b.time)
+			}
+			return a.name < b.name
+		})
+		victims = append(victims, idx[maxCount:]...)
+	}
+	sort.Ints(victims)
+	return victims
+}
+
+// updateStatus reports the outcome of the pass in the status of the policies.
+func (c *Cleaner) updateStatus(ctx context.Context, ps policySet, now time.Time) []error {
+	var errs []error
+	for _, p := range ps {
+		orig := p.DeepCopy()
+		p.Status = api.FalcoRetentionPolicyStatus{
+			ObservedGeneration: p.Generation,
+			MatchedEvents:      p.matched,
+			DeletedEvents:      p.deleted,
+			LastEvaluationTime: &metav1.Time{Time: now},
+		}
+		err := c.kc.Status().Patch(ctx, p.FalcoRetentionPolicy, client.MergeFrom(orig))
+		if err != nil && !apierrors.IsNotFound(err) {
+			failures.WithLabelValues(stageStatus).Inc()
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
@@ -0,0 +1,88 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmds
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"kubeops.dev/falco-ui-server/pkg/apiserver"
+	"kubeops.dev/falco-ui-server/pkg/archive"
+
+	"github.com/spf13/cobra"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func NewCmdRestore(ctx context.Context, out io.Writer) *cobra.Command {
+	o := archive.NewOptions()
+	var (
+		kubeconfig   string
+		since, until string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "restore",
+		Short: "Restore archived FalcoEvents",
+		Long: "Re-creates the archived FalcoEvents with an event time in [--since, --until) for investigation. " +
+			"Restored events are kept for another --event-ttl and are not archived again.",
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !o.Enabled() {
+				return fmt.Errorf("one of --archive-dir or --archive-s3-endpoint must be set")
+			}
+			if err := utilerrors.NewAggregate(o.Validate()); err != nil {
+				return err
+			}
+			var from, to time.Time
+			var err error
+			if since != "" {
+				if from, err = time.Parse(time.RFC3339, since); err != nil {
+					return fmt.Errorf("invalid --since: %v", err)
+				}
+			}
+			if until != "" {
+				if to, err = time.Parse(time.RFC3339, until); err != nil {
+					return fmt.Errorf("invalid --until: %v", err)
+				}
+			}
+
+			cfg, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+			if err != nil {
+				return err
+			}
+			kc, err := client.New(cfg, client.Options{Scheme: apiserver.Scheme})
+			if err != nil {
+				return err
+			}
+			restored, skipped, err := archive.Restore(ctx, kc, o.NewSink(), o.Prefix, from, to)
+			fmt.Fprintf(out, "restored %d FalcoEvents, skipped %d existing\n", restored, skipped)
+			return err
+		},
+	}
+
+	flags := cmd.Flags()
+	o.AddFlags(flags)
+	flags.StringVar(&kubeconfig, "kubeconfig", kubeconfig, "Path to a kubeconfig. Defaults to the in-cluster config.")
+	flags.StringVar(&since, "since", since, "Restore events at or after this RFC 3339 time")
+	flags.StringVar(&until, "until", until, "Restore events before this RFC 3339 time")
+
+	return cmd
+}
@@ -17,29 +17,100 @@ limitations under the License.
 package server
 
 import (
+	"fmt"
+	"net/url"
 	"time"
 
 	"kubeops.dev/falco-ui-server/pkg/apiserver"
+	"kubeops.dev/falco-ui-server/pkg/archive"
+	"kubeops.dev/falco-ui-server/pkg/cleaner"
+	"kubeops.dev/falco-ui-server/pkg/eventstore"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+	"kubeops.dev/falco-ui-server/pkg/listener"
+	"kubeops.dev/falco-ui-server/pkg/quota"
+	festorage "kubeops.dev/falco-ui-server/pkg/registry/falco/falcoevent"
+	"kubeops.dev/falco-ui-server/pkg/retention"
 
 	"github.com/spf13/pflag"
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 )
 
+// maxEventSize leaves room for the JSON quoting and escaping of the output fields of an event.
+const maxEventSize = festorage.MaxOutputFieldsLength / 2
+
 type ExtraOptions struct {
 	QPS          float64
 	Burst        int
 	ResyncPeriod time.Duration
 
-	EventTTLPeriod time.Duration
+	EventTTLPeriod  time.Duration
+	RetentionPolicy string
+	IngestUsers     []string
+
+	MaxStoredEvents      int
+	MaxStoredEventsBytes int64
+	CapacityLowWatermark float64
+
+	CleanerInterval         time.Duration
+	CleanerPageSize         int64
+	CleanerWorkers          int
+	CleanerDryRun           bool
+	LeaderElection          bool
+	LeaderElectionNamespace string
+
+	TableColumns     []string
+	WideTableColumns []string
+
+	StorageBackend  string
+	SegmentDir      string
+	SegmentDuration time.Duration
+
+	MaxPayloadSize     int64
+	MaxOutputFieldSize int
+	MaxEventSize       int
+
+	NamespaceEventQuota  int
+	NamespaceEventQuotas map[string]int
+	NodeEventQuota       int
+	NodeEventQuotas      map[string]int
+
+	RecorderMinPriority string
+
+	UIURL string
+
+	Archive *archive.Options
+
+	MetricsListener *listener.Options
+	IngestListener  *listener.Options
 }
 
 func NewExtraOptions() *ExtraOptions {
 	return &ExtraOptions{
-		ResyncPeriod:   10 * time.Minute,
-		QPS:            1e6,
-		Burst:          1e6,
-		EventTTLPeriod: 24 * time.Hour,
+		ResyncPeriod:    10 * time.Minute,
+		QPS:             1e6,
+		Burst:           1e6,
+		EventTTLPeriod:  24 * time.Hour,
+		StorageBackend:  apiserver.StorageBackendEtcd,
+		SegmentDir:      "/var/lib/falco-ui-server/falcoevents",
+		SegmentDuration: eventstore.DefaultSegmentDuration,
+
+		MaxPayloadSize:     falcosidekick.DefaultMaxBodySize,
+		MaxOutputFieldSize: falcosidekick.DefaultMaxFieldSize,
+		MaxEventSize:       falcosidekick.DefaultMaxEventSize,
+
+		CapacityLowWatermark: cleaner.DefaultLowWatermark,
+
+		CleanerInterval: cleaner.DefaultInterval,
+		CleanerPageSize: cleaner.DefaultPageSize,
+		CleanerWorkers:  cleaner.DefaultWorkers,
+		LeaderElection:  true,
+
+		Archive: archive.NewOptions(),
+
+		MetricsListener: listener.NewOptions("metrics", ":8080"),
+		IngestListener:  listener.NewOptions("ingest", ""),
 	}
 }
 
@@ -49,15 +120,88 @@ func (s *ExtraOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&s.Burst, "burst", s.Burst, "The maximum burst for throttle")
 
 	fs.DurationVar(&s.EventTTLPeriod, "event-ttl", s.EventTTLPeriod, "Events older than this period will be garbage collected")
+	fs.StringVar(&s.RetentionPolicy, "retention-policy", s.RetentionPolicy, "Path to a YAML file with TTLs per priority and overrides per rule, priority, source and namespace label. --event-ttl is used as the default TTL.")
+	fs.IntVar(&s.MaxStoredEvents, "max-stored-events", s.MaxStoredEvents, "Maximum number of stored FalcoEvents. When exceeded, the cleaner evicts the lowest priority and then the oldest events. Zero means unlimited.")
+	fs.Int64Var(&s.MaxStoredEventsBytes, "max-stored-events-bytes", s.MaxStoredEventsBytes, "Maximum approximate size in bytes of the stored FalcoEvents. Zero means unlimited.")
+	fs.Float64Var(&s.CapacityLowWatermark, "capacity-low-watermark", s.CapacityLowWatermark, "Fraction of --max-stored-events and --max-stored-events-bytes the cleaner evicts down to once a limit is exceeded")
+
+	fs.DurationVar(&s.CleanerInterval, "cleaner-interval", s.CleanerInterval, "Time between two passes of the FalcoEvent cleaner")
+	fs.Int64Var(&s.CleanerPageSize, "cleaner-page-size", s.CleanerPageSize, "Number of FalcoEvents the cleaner lists at a time")
+	fs.IntVar(&s.CleanerWorkers, "cleaner-workers", s.CleanerWorkers, "Number of FalcoEvents the cleaner deletes concurrently")
+	fs.BoolVar(&s.CleanerDryRun, "cleaner-dry-run", s.CleanerDryRun, "If true, the cleaner only logs the FalcoEvents it would archive and delete")
+	fs.BoolVar(&s.LeaderElection, "leader-elect", s.LeaderElection, "If true, the cleaner only runs on the elected leader among the replicas")
+	fs.StringVar(&s.LeaderElectionNamespace, "leader-election-namespace", s.LeaderElectionNamespace, "Namespace of the leader election lease. Defaults to the namespace of the pod, it must be set when running outside of a cluster.")
+	fs.StringSliceVar(&s.IngestUsers, "ingest-users", s.IngestUsers, "Users allowed to update the spec of existing FalcoEvents. Defaults to the identity of this server.")
+	fs.StringSliceVar(&s.TableColumns, "table-columns", s.TableColumns, "Falco output fields shown as additional columns by kubectl, given as [name=]field, eg, File=fd.name")
+	fs.StringSliceVar(&s.WideTableColumns, "wide-table-columns", s.WideTableColumns, "Falco output fields shown as additional columns by kubectl with -o wide, given as [name=]field")
+
+	fs.StringVar(&s.StorageBackend, "storage-backend", s.StorageBackend, fmt.Sprintf("Storage backend for FalcoEvents, one of %q or %q. The %q backend keeps FalcoEvents in time partitioned segment files on local disk instead of etcd, it supports a single replica only.", apiserver.StorageBackendEtcd, apiserver.StorageBackendSegment, apiserver.StorageBackendSegment))
+	fs.StringVar(&s.SegmentDir, "segment-storage-dir", s.SegmentDir, "Directory the segment storage backend writes FalcoEvents to")
+	fs.DurationVar(&s.SegmentDuration, "segment-duration", s.SegmentDuration, "Time span of the FalcoEvents stored in one segment. Expired segments are dropped as a whole.")
+
+	fs.Int64Var(&s.MaxPayloadSize, "max-payload-size", s.MaxPayloadSize, "Maximum size in bytes of a Falco payload accepted by the ingest handler. Zero disables the limit.")
+	fs.IntVar(&s.MaxOutputFieldSize, "max-output-field-size", s.MaxOutputFieldSize, fmt.Sprintf("Size in bytes the output and each output field of a Falco event are truncated to. At most %d bytes, the size of the output accepted by the api server.", festorage.MaxOutputLength))
+	fs.IntVar(&s.MaxEventSize, "max-event-size", s.MaxEventSize, fmt.Sprintf("Size in bytes the output and output fields of a Falco event are truncated to in total, largest fields first. At most %d bytes, half the size of the output fields accepted by the api server.", maxEventSize))
+
+	fs.IntVar(&s.NamespaceEventQuota, "namespace-event-quota", s.NamespaceEventQuota, "Maximum number of FalcoEvents stored per namespace. When exceeded, the lowest priority and then the oldest events are evicted. Zero means unlimited.")
+	fs.StringToIntVar(&s.NamespaceEventQuotas, "namespace-event-quotas", s.NamespaceEventQuotas, "Per namespace overrides of --namespace-event-quota, eg, kube-system=5000,default=100")
+	fs.IntVar(&s.NodeEventQuota, "node-event-quota", s.NodeEventQuota, "Maximum number of host FalcoEvents, ie, events without a namespace, stored per node. Zero means unlimited.")
+	fs.StringToIntVar(&s.NodeEventQuotas, "node-event-quotas", s.NodeEventQuotas, "Per node overrides of --node-event-quota")
+
+	fs.StringVar(&s.RecorderMinPriority, "event-recorder-min-priority", s.RecorderMinPriority, "If set, new FalcoEvents at or above this priority are mirrored as Warning core/v1 Events on the involved Pod, or the Node for host events")
+
+	fs.StringVar(&s.UIURL, "ui-url", s.UIURL, "Base URL of the UI. If set, the outputs link to the FalcoEvents at <ui-url>/falcoevents/<name>, otherwise they show the kubectl command getting them.")
+
+	s.Archive.AddFlags(fs)
+	s.MetricsListener.AddFlags(fs)
+	s.IngestListener.AddFlags(fs)
 }
 
 func (s *ExtraOptions) ApplyTo(cfg *apiserver.ExtraConfig) error {
+	var err error
 	cfg.ClientConfig.QPS = float32(s.QPS)
 	cfg.ClientConfig.Burst = s.Burst
 	cfg.ResyncPeriod = s.ResyncPeriod
 	cfg.EventTTLPeriod = s.EventTTLPeriod
+	if cfg.RetentionPolicy, err = s.retentionPolicy(); err != nil {
+		return err
+	}
+	cfg.IngestUsers = s.IngestUsers
+	cfg.Capacity = cleaner.Capacity{
+		MaxEvents:    s.MaxStoredEvents,
+		MaxBytes:     s.MaxStoredEventsBytes,
+		LowWatermark: s.CapacityLowWatermark,
+	}
+	cfg.CleanerInterval = s.CleanerInterval
+	cfg.CleanerPageSize = s.CleanerPageSize
+	cfg.CleanerWorkers = s.CleanerWorkers
+	cfg.CleanerDryRun = s.CleanerDryRun
+	cfg.LeaderElection = s.LeaderElection
+	cfg.LeaderElectionNamespace = s.LeaderElectionNamespace
+	cfg.StorageBackend = s.StorageBackend
+	cfg.SegmentDir = s.SegmentDir
+	cfg.SegmentDuration = s.SegmentDuration
+	cfg.PayloadLimits = falcosidekick.Limits{
+		MaxBodySize:  s.MaxPayloadSize,
+		MaxFieldSize: s.MaxOutputFieldSize,
+		MaxEventSize: s.MaxEventSize,
+	}
+	cfg.RecorderMinPriority = s.RecorderMinPriority
+	cfg.UIURL = s.UIURL
+	cfg.ArchiveSink = s.Archive.NewSink()
+	cfg.ArchivePrefix = s.Archive.Prefix
+	cfg.MetricsListener = *s.MetricsListener
+	cfg.IngestListener = *s.IngestListener
+	cfg.Quota = quota.Config{
+		NamespaceLimit:  s.NamespaceEventQuota,
+		NamespaceLimits: s.NamespaceEventQuotas,
+		NodeLimit:       s.NodeEventQuota,
+		NodeLimits:      s.NodeEventQuotas,
+	}
 
-	var err error
+	if cfg.TableColumns, err = s.tableColumns(); err != nil {
+		return err
+	}
 	if cfg.KubeClient, err = kubernetes.NewForConfig(cfg.ClientConfig); err != nil {
 		return err
 	}
@@ -67,5 +211,104 @@ func (s *ExtraOptions) ApplyTo(cfg *apiserver.ExtraConfig) error {
 }
 
 func (s *ExtraOptions) Validate() []error {
-	return nil
+	var errs []error
+	if _, err := s.tableColumns(); err != nil {
+		errs = append(errs, err)
+	}
+	if s.EventTTLPeriod <= 0 {
+		errs = append(errs, fmt.Errorf("--event-ttl must be positive"))
+	} else if _, err := s.retentionPolicy(); err != nil {
+		errs = append(errs, err)
+	}
+	if s.MaxStoredEvents < 0 || s.MaxStoredEventsBytes < 0 {
+		errs = append(errs, fmt.Errorf("capacity limits must not be negative"))
+	}
+	if s.CapacityLowWatermark <= 0 || s.CapacityLowWatermark > 1 {
+		errs = append(errs, fmt.Errorf("--capacity-low-watermark must be in (0, 1]"))
+	}
+	if s.CleanerInterval <= 0 || s.CleanerPageSize <= 0 || s.CleanerWorkers <= 0 {
+		errs = append(errs, fmt.Errorf("--cleaner-interval, --cleaner-page-size and --cleaner-workers must be positive"))
+	}
+	if s.MaxPayloadSize < 0 {
+		errs = append(errs, fmt.Errorf("--max-payload-size must not be negative"))
+	}
+	// events exceeding the size validated by the api server would be lost, so truncation can not be disabled
+	if s.MaxOutputFieldSize <= 0 || s.MaxOutputFieldSize > festorage.MaxOutputLength {
+		errs = append(errs, fmt.Errorf("--max-output-field-size must be in [1, %d]", festorage.MaxOutputLength))
+	}
+	if s.MaxEventSize <= 0 || s.MaxEventSize > maxEventSize {
+		errs = append(errs, fmt.Errorf("--max-event-size must be in [1, %d]", maxEventSize))
+	}
+	if s.NamespaceEventQuota < 0 || s.NodeEventQuota < 0 {
+		errs = append(errs, fmt.Errorf("event quotas must not be negative"))
+	}
+	if s.RecorderMinPriority != "" && types.Priority(s.RecorderMinPriority) == types.Default {
+		errs = append(errs, fmt.Errorf("unknown priority %q for --event-recorder-min-priority", s.RecorderMinPriority))
+	}
+	if s.UIURL != "" {
+		if u, err := url.Parse(s.UIURL); err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+			errs = append(errs, fmt.Errorf("--ui-url must be an http or https url"))
+		}
+	}
+	switch s.StorageBackend {
+	case apiserver.StorageBackendEtcd:
+	case apiserver.StorageBackendSegment:
+		if s.SegmentDir == "" {
+			errs = append(errs, fmt.Errorf("--segment-storage-dir must be set for the %q storage backend", s.StorageBackend))
+		}
+		if s.SegmentDuration <= 0 {
+			errs = append(errs, fmt.Errorf("--segment-duration must be positive"))
+		}
+		if s.Archive.Enabled() {
+			errs = append(errs, fmt.Errorf("archiving is not supported by the %q storage backend", s.StorageBackend))
+		}
+		if s.RetentionPolicy != "" {
+			errs = append(errs, fmt.Errorf("--retention-policy is not supported by the %q storage backend", s.StorageBackend))
+		}
+		if s.MaxStoredEvents > 0 || s.MaxStoredEventsBytes > 0 {
+			errs = append(errs, fmt.Errorf("capacity limits are not supported by the %q storage backend", s.StorageBackend))
+		}
+		// the segments are local to a replica, replicas would serve diverging events
+		if s.LeaderElection {
+			errs = append(errs, fmt.Errorf("the %q storage backend runs a single replica and requires --leader-elect=false", s.StorageBackend))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("unknown storage backend %q", s.StorageBackend))
+	}
+	errs = append(errs, s.Archive.Validate()...)
+	errs = append(errs, s.MetricsListener.Validate()...)
+	errs = append(errs, s.IngestListener.Validate()...)
+	switch {
+	case !s.IngestListener.Enabled() && !s.MetricsListener.Enabled():
+		errs = append(errs, fmt.Errorf("the ingest handler is served on the metrics listener unless --ingest-bind-address is set, at least one of them must be enabled"))
+	case s.IngestListener.Enabled() && s.IngestListener.BindAddress == s.MetricsListener.BindAddress:
+		errs = append(errs, fmt.Errorf("--ingest-bind-address and --metrics-bind-address must differ"))
+	}
+	return errs
+}
+
+func (s *ExtraOptions) retentionPolicy() (*retention.Policy, error) {
+	if s.RetentionPolicy == "" {
+		return retention.NewPolicy(s.EventTTLPeriod), nil
+	}
+	return retention.Load(s.RetentionPolicy, s.EventTTLPeriod)
+}
+
+func (s *ExtraOptions) tableColumns() ([]festorage.OutputFieldColumn, error) {
+	columns := make([]festorage.OutputFieldColumn, 0, len(s.TableColumns)+len(s.WideTableColumns))
+	for _, c := range s.TableColumns {
+		col, err := festorage.ParseOutputFieldColumn(c, false)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	for _, c := range s.WideTableColumns {
+		col, err := festorage.ParseOutputFieldColumn(c, true)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
 }
@@ -20,9 +20,11 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"maps"
 	"net"
 
 	api "kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
+	apiv1beta1 "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
 	"kubeops.dev/falco-ui-server/pkg/apiserver"
 
 	"github.com/spf13/pflag"
@@ -32,6 +34,7 @@ import (
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	genericoptions "k8s.io/apiserver/pkg/server/options"
 	basecompatibility "k8s.io/component-base/compatibility"
+	"k8s.io/kube-openapi/pkg/common"
 	"kmodules.xyz/client-go/tools/clientcmd"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/manager"
@@ -39,6 +42,13 @@ import (
 
 const defaultEtcdPathPrefix = "/registry/falco.appscode.com"
 
+// getOpenAPIDefinitions returns the OpenAPI definitions of all served versions.
+func getOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
+	defs := api.GetOpenAPIDefinitions(ref)
+	maps.Copy(defs, apiv1beta1.GetOpenAPIDefinitions(ref))
+	return defs
+}
+
 // FalcoUIServerOptions contains state for master/api server
 type FalcoUIServerOptions struct {
 	RecommendedOptions *genericoptions.RecommendedOptions
@@ -53,6 +63,9 @@ func NewFalcoUIServerOptions(out, errOut io.Writer) *FalcoUIServerOptions {
 	o := &FalcoUIServerOptions{
 		RecommendedOptions: genericoptions.NewRecommendedOptions(
 			defaultEtcdPathPrefix,
+			// FalcoEvents are still stored as v1alpha1, so a rollback to the previous release can read
+			// them. Storing them as v1beta1 is left to a later release, whose release notes must ask
+			// to migrate the stored objects before v1alpha1 is dropped.
 			apiserver.Codecs.LegacyCodec(
 				api.SchemeGroupVersion,
 			),
@@ -81,6 +94,10 @@ func (o FalcoUIServerOptions) Validate(args []string) error {
 
 // Complete fills in fields required to have valid data
 func (o *FalcoUIServerOptions) Complete() error {
+	// FalcoEvents are the only resource served, etcd is not needed if they are kept elsewhere
+	if o.ExtraOptions.StorageBackend == apiserver.StorageBackendSegment {
+		o.RecommendedOptions.Etcd = nil
+	}
 	return nil
 }
 
@@ -101,12 +118,13 @@ func (o *FalcoUIServerOptions) Config() (*apiserver.Config, error) {
 	ignorePrefixes := []string{
 		"/swaggerapi",
 		fmt.Sprintf("/apis/%s/%s", api.SchemeGroupVersion, api.ResourceFalcoEvents),
+		fmt.Sprintf("/apis/%s/%s", apiv1beta1.SchemeGroupVersion, apiv1beta1.ResourceFalcoEvents),
 	}
 
 	serverConfig.EffectiveVersion = basecompatibility.NewEffectiveVersionFromString("v1.0.0", "", "")
 
 	serverConfig.OpenAPIConfig = genericapiserver.DefaultOpenAPIConfig(
-		api.GetOpenAPIDefinitions,
+		getOpenAPIDefinitions,
 		openapi.NewDefinitionNamer(apiserver.Scheme),
 	)
 	serverConfig.OpenAPIConfig.Info.Title = "Falco UI Server"
@@ -114,7 +132,7 @@ func (o *FalcoUIServerOptions) Config() (*apiserver.Config, error) {
 	serverConfig.OpenAPIConfig.IgnorePrefixes = ignorePrefixes
 
 	serverConfig.OpenAPIV3Config = genericapiserver.DefaultOpenAPIV3Config(
-		api.GetOpenAPIDefinitions,
+		getOpenAPIDefinitions,
 		openapi.NewDefinitionNamer(apiserver.Scheme),
 	)
 	serverConfig.OpenAPIV3Config.Info.Title = "Falco UI Server"
@@ -153,7 +171,8 @@ func (o FalcoUIServerOptions) Run(ctx context.Context) error {
 		return nil
 	})
 
-	err = server.Manager.Add(manager.RunnableFunc(func(ctx context.Context) error {
+	// every replica serves the api, only the cleaner runs on the leader
+	err = server.Manager.Add(nonLeaderRunnable(func(ctx context.Context) error {
 		return server.GenericAPIServer.PrepareRun().RunWithContext(ctx)
 	}))
 	if err != nil {
@@ -170,3 +189,16 @@ func (o FalcoUIServerOptions) Run(ctx context.Context) error {
 	<-ctx.Done()
 	return nil
 }
+
+// nonLeaderRunnable is a manager.Runnable that runs on every replica, regardless of leader election.
+type nonLeaderRunnable func(ctx context.Context) error
+
+var _ manager.LeaderElectionRunnable = nonLeaderRunnable(nil)
+
+func (r nonLeaderRunnable) Start(ctx context.Context) error {
+	return r(ctx)
+}
+
+func (r nonLeaderRunnable) NeedLeaderElection() bool {
+	return false
+}
@@ -0,0 +1,91 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstore
+
+import (
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apiserver/pkg/registry/generic"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/storagebackend"
+	"k8s.io/apiserver/pkg/storage/storagebackend/factory"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	DefaultSegmentDuration = time.Hour
+	DefaultWatchHistory    = 10000
+)
+
+// Config configures a store that keeps objects in time partitioned segment files on disk
+// instead of etcd. The files are local to a replica and not replicated, so every replica
+// serves its own objects and replicas running side by side diverge.
+type Config struct {
+	// Dir is the directory the segment files are written to.
+	Dir string
+	// SegmentDuration is the time span covered by a segment.
+	SegmentDuration time.Duration
+	// Retention is the age after which a segment is dropped. Zero keeps segments forever.
+	Retention time.Duration
+	// Codec encodes the objects in their storage version.
+	Codec runtime.Codec
+	// TimeFunc returns the time an object is partitioned by.
+	TimeFunc func(obj runtime.Object) time.Time
+	// LabelIndexes and FieldIndexes are indexed to serve lists selecting on them.
+	LabelIndexes []string
+	FieldIndexes []string
+	// WatchHistory is the number of recent changes kept to resume watches.
+	WatchHistory int
+}
+
+var _ generic.RESTOptionsGetter = Config{}
+
+// GetRESTOptions implements generic.RESTOptionsGetter. The returned options make the generic
+// registry store its objects in a segment store.
+func (c Config) GetRESTOptions(resource schema.GroupResource, _ runtime.Object) (generic.RESTOptions, error) {
+	return generic.RESTOptions{
+		StorageConfig: &storagebackend.ConfigForResource{
+			Config: storagebackend.Config{
+				Codec: c.Codec,
+			},
+			GroupResource: resource,
+		},
+		Decorator:               c.decorate,
+		DeleteCollectionWorkers: 1,
+		ResourcePrefix:          "/" + resource.Group + "/" + resource.Resource,
+	}, nil
+}
+
+// decorate implements generic.StorageDecorator.
+func (c Config) decorate(
+	config *storagebackend.ConfigForResource,
+	resourcePrefix string,
+	_ func(obj runtime.Object) (string, error),
+	newFunc func() runtime.Object,
+	newListFunc func() runtime.Object,
+	getAttrsFunc storage.AttrFunc,
+	_ storage.IndexerFuncs,
+	_ *cache.Indexers,
+) (storage.Interface, factory.DestroyFunc, error) {
+	s, err := newStore(c, config.Codec, resourcePrefix, newFunc, newListFunc, getAttrsFunc)
+	if err != nil {
+		return nil, nil, err
+	}
+	return s, s.destroy, nil
+}
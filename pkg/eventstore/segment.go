@@ -0,0 +1,181 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstore
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	segmentExt = ".seg"
+
+	opPut    = "put"
+	opDelete = "delete"
+
+	// recordPrefixSize is the size of the header and object lengths in front of every record.
+	recordPrefixSize = 8
+	// maxHeaderSize guards against reading garbage as a record header.
+	maxHeaderSize = 1 << 20
+)
+
+// recordHeader is written in front of every object in a segment. The labels and fields
+// let the store rebuild its indexes on startup without decoding the objects.
+type recordHeader struct {
+	Op     string            `json:"op"`
+	Key    string            `json:"key"`
+	RV     uint64            `json:"rv"`
+	Labels map[string]string `json:"labels,omitempty"`
+	Fields map[string]string `json:"fields,omitempty"`
+}
+
+// segment is an append only file holding the objects of one time partition.
+type segment struct {
+	start time.Time
+	path  string
+	f     *os.File
+	size  int64
+}
+
+func segmentPath(dir string, start time.Time) string {
+	return filepath.Join(dir, strconv.FormatInt(start.Unix(), 10)+segmentExt)
+}
+
+// parseSegmentName returns the start of the time partition stored in the named file.
+func parseSegmentName(name string) (time.Time, bool) {
+	if !strings.HasSuffix(name, segmentExt) {
+		return time.Time{}, false
+	}
+	sec, err := strconv.ParseInt(strings.TrimSuffix(name, segmentExt), 10, 64)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return time.Unix(sec, 0).UTC(), true
+}
+
+func openSegment(dir string, start time.Time) (*segment, error) {
+	path := segmentPath(dir, start)
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &segment{start: start, path: path, f: f, size: fi.Size()}, nil
+}
+
+// append writes a record and returns the offset of the object within the segment.
+func (s *segment) append(h recordHeader, obj []byte) (int64, error) {
+	hdr, err := json.Marshal(h)
+	if err != nil {
+		return 0, err
+	}
+	buf := make([]byte, recordPrefixSize, recordPrefixSize+len(hdr)+len(obj))
+	binary.BigEndian.PutUint32(buf[0:4], uint32(len(hdr)))
+	binary.BigEndian.PutUint32(buf[4:8], uint32(len(obj)))
+	buf = append(buf, hdr...)
+	buf = append(buf, obj...)
+	if _, err := s.f.Write(buf); err != nil {
+		return 0, err
+	}
+	offset := s.size + recordPrefixSize + int64(len(hdr))
+	s.size += int64(len(buf))
+	return offset, nil
+}
+
+func (s *segment) read(offset int64, length int) ([]byte, error) {
+	data := make([]byte, length)
+	if _, err := s.f.ReadAt(data, offset); err != nil {
+		return nil, fmt.Errorf("failed to read %s at %d: %w", s.path, offset, err)
+	}
+	return data, nil
+}
+
+// scan calls fn for every record in the segment. A torn record at the end of the
+// segment, eg, left behind by a crash, is truncated.
+func (s *segment) scan(fn func(h recordHeader, offset int64, length int)) error {
+	r := bufio.NewReader(io.NewSectionReader(s.f, 0, s.size))
+	var pos int64
+	prefix := make([]byte, recordPrefixSize)
+	for pos < s.size {
+		if _, err := io.ReadFull(r, prefix); err != nil {
+			return s.truncate(pos, err)
+		}
+		hdrLen := binary.BigEndian.Uint32(prefix[0:4])
+		objLen := binary.BigEndian.Uint32(prefix[4:8])
+		if hdrLen > maxHeaderSize {
+			return s.truncate(pos, fmt.Errorf("header of %d bytes", hdrLen))
+		}
+		hdr := make([]byte, hdrLen)
+		if _, err := io.ReadFull(r, hdr); err != nil {
+			return s.truncate(pos, err)
+		}
+		var h recordHeader
+		if err := json.Unmarshal(hdr, &h); err != nil {
+			return s.truncate(pos, err)
+		}
+		offset := pos + recordPrefixSize + int64(hdrLen)
+		if offset+int64(objLen) > s.size {
+			return s.truncate(pos, io.ErrUnexpectedEOF)
+		}
+		if _, err := r.Discard(int(objLen)); err != nil {
+			return s.truncate(pos, err)
+		}
+		fn(h, offset, int(objLen))
+		pos = offset + int64(objLen)
+	}
+	return nil
+}
+
+func (s *segment) truncate(pos int64, cause error) error {
+	if errors.Is(cause, io.EOF) {
+		cause = io.ErrUnexpectedEOF
+	}
+	klog.Warningf("truncating segment %s at offset %d, reason: %v", s.path, pos, cause)
+	if err := s.f.Truncate(pos); err != nil {
+		return err
+	}
+	s.size = pos
+	return nil
+}
+
+func (s *segment) close() error {
+	if err := s.f.Sync(); err != nil {
+		_ = s.f.Close()
+		return err
+	}
+	return s.f.Close()
+}
+
+func (s *segment) remove() error {
+	_ = s.f.Close()
+	return os.Remove(s.path)
+}
@@ -0,0 +1,779 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/conversion"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+	"k8s.io/klog/v2"
+)
+
+// rvFile holds the last resource version, so that it survives dropping all segments.
+const rvFile = "resourceversion"
+
+// entry locates the current version of an object.
+type entry struct {
+	key    string
+	rv     uint64
+	seg    *segment
+	offset int64
+	length int
+	labels labels.Set
+	fields fields.Set
+}
+
+// store implements storage.Interface on top of time partitioned segment files. All objects
+// are located through an in-memory index, only the objects themselves are read from disk.
+//
+// Appends are not fsynced, so the latest changes may be lost on a node crash. Segments are
+// never compacted, the outdated versions of updated and deleted objects take up space until
+// their segment expires as a whole. Objects move between segments as their time changes, so
+// deletions are recorded in the newest segment, which outlives all older versions of an object.
+type store struct {
+	cfg         Config
+	codec       runtime.Codec
+	versioner   storage.Versioner
+	prefix      string
+	newFunc     func() runtime.Object
+	newListFunc func() runtime.Object
+	getAttrs    storage.AttrFunc
+
+	mu           sync.RWMutex
+	rv           uint64
+	entries      map[string]*entry
+	segments     map[int64]*segment
+	labelIndexes map[string]map[string]sets.Set[string]
+	fieldIndexes map[string]map[string]sets.Set[string]
+	size         int64
+
+	// compacted is the newest resource version no longer kept in the watch history.
+	compacted uint64
+	history   []event
+	watchers  map[int]*watcher
+	watcherID int
+
+	stopCh   chan struct{}
+	stopOnce sync.Once
+}
+
+var _ storage.Interface = &store{}
+
+func newStore(cfg Config, codec runtime.Codec, prefix string, newFunc, newListFunc func() runtime.Object, getAttrs storage.AttrFunc) (*store, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("missing segment store directory")
+	}
+	if cfg.TimeFunc == nil {
+		return nil, fmt.Errorf("missing segment store time func")
+	}
+	if cfg.SegmentDuration <= 0 {
+		cfg.SegmentDuration = DefaultSegmentDuration
+	}
+	if cfg.WatchHistory <= 0 {
+		cfg.WatchHistory = DefaultWatchHistory
+	}
+	if err := os.MkdirAll(cfg.Dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	s := &store{
+		cfg:          cfg,
+		codec:        codec,
+		versioner:    storage.APIObjectVersioner{},
+		prefix:       prefix,
+		newFunc:      newFunc,
+		newListFunc:  newListFunc,
+		getAttrs:     getAttrs,
+		entries:      map[string]*entry{},
+		segments:     map[int64]*segment{},
+		labelIndexes: map[string]map[string]sets.Set[string]{},
+		fieldIndexes: map[string]map[string]sets.Set[string]{},
+		watchers:     map[int]*watcher{},
+		stopCh:       make(chan struct{}),
+	}
+	for _, name := range cfg.LabelIndexes {
+		s.labelIndexes[name] = map[string]sets.Set[string]{}
+	}
+	for _, name := range cfg.FieldIndexes {
+		s.fieldIndexes[name] = map[string]sets.Set[string]{}
+	}
+	if err := s.load(); err != nil {
+		s.closeSegments()
+		return nil, err
+	}
+
+	if cfg.Retention > 0 {
+		go wait.Until(func() {
+			if err := s.dropBefore(time.Now().Add(-cfg.Retention)); err != nil {
+				klog.ErrorS(err, "failed to drop expired segments", "dir", cfg.Dir)
+			}
+		}, time.Minute, s.stopCh)
+	}
+	return s, nil
+}
+
+// load rebuilds the index by replaying all records in resource version order.
+func (s *store) load() error {
+	if data, err := os.ReadFile(filepath.Join(s.cfg.Dir, rvFile)); err == nil {
+		if s.rv, err = strconv.ParseUint(strings.TrimSpace(string(data)), 10, 64); err != nil {
+			return fmt.Errorf("invalid %s file: %v", rvFile, err)
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	files, err := os.ReadDir(s.cfg.Dir)
+	if err != nil {
+		return err
+	}
+	type record struct {
+		h      recordHeader
+		seg    *segment
+		offset int64
+		length int
+	}
+	var records []record
+	for _, f := range files {
+		start, ok := parseSegmentName(f.Name())
+		if !ok || f.IsDir() {
+			continue
+		}
+		seg, err := openSegment(s.cfg.Dir, start)
+		if err != nil {
+			return err
+		}
+		s.segments[start.Unix()] = seg
+		err = seg.scan(func(h recordHeader, offset int64, length int) {
+			records = append(records, record{h: h, seg: seg, offset: offset, length: length})
+		})
+		if err != nil {
+			return err
+		}
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].h.RV < records[j].h.RV
+	})
+
+	for _, r := range records {
+		if old, ok := s.entries[r.h.Key]; ok {
+			s.unindex(old)
+		}
+		if r.h.RV > s.rv {
+			s.rv = r.h.RV
+		}
+		if r.h.Op == opDelete {
+			continue
+		}
+		s.index(&entry{
+			key:    r.h.Key,
+			rv:     r.h.RV,
+			seg:    r.seg,
+			offset: r.offset,
+			length: r.length,
+			labels: r.h.Labels,
+			fields: r.h.Fields,
+		})
+	}
+	s.compacted = s.rv
+	klog.InfoS("loaded segment store", "dir", s.cfg.Dir, "segments", len(s.segments), "objects", len(s.entries), "resourceVersion", s.rv)
+	return nil
+}
+
+func (s *store) index(e *entry) {
+	s.entries[e.key] = e
+	s.size += int64(e.length)
+	for name, idx := range s.labelIndexes {
+		addToIndex(idx, e.labels[name], e.key)
+	}
+	for name, idx := range s.fieldIndexes {
+		addToIndex(idx, e.fields[name], e.key)
+	}
+}
+
+func (s *store) unindex(e *entry) {
+	delete(s.entries, e.key)
+	s.size -= int64(e.length)
+	for name, idx := range s.labelIndexes {
+		removeFromIndex(idx, e.labels[name], e.key)
+	}
+	for name, idx := range s.fieldIndexes {
+		removeFromIndex(idx, e.fields[name], e.key)
+	}
+}
+
+func addToIndex(idx map[string]sets.Set[string], value, key string) {
+	if value == "" {
+		return
+	}
+	if idx[value] == nil {
+		idx[value] = sets.New[string]()
+	}
+	idx[value].Insert(key)
+}
+
+func removeFromIndex(idx map[string]sets.Set[string], value, key string) {
+	if keys, ok := idx[value]; ok {
+		keys.Delete(key)
+		if keys.Len() == 0 {
+			delete(idx, value)
+		}
+	}
+}
+
+// segmentFor returns the segment an object with the given time is written to.
+func (s *store) segmentFor(t time.Time) (*segment, error) {
+	start := t.UTC().Truncate(s.cfg.SegmentDuration)
+	if seg, ok := s.segments[start.Unix()]; ok {
+		return seg, nil
+	}
+	seg, err := openSegment(s.cfg.Dir, start)
+	if err != nil {
+		return nil, err
+	}
+	s.segments[start.Unix()] = seg
+	return seg, nil
+}
+
+// put writes a new version of an object and notifies the watchers. It must be called with the lock held.
+func (s *store) put(key string, data []byte, l labels.Set, f fields.Set, t time.Time, prev *entry, prevData []byte) (*entry, error) {
+	seg, err := s.segmentFor(t)
+	if err != nil {
+		return nil, err
+	}
+	rv := s.rv + 1
+	offset, err := seg.append(recordHeader{Op: opPut, Key: key, RV: rv, Labels: l, Fields: f}, data)
+	if err != nil {
+		return nil, err
+	}
+	s.rv = rv
+
+	e := &entry{key: key, rv: rv, seg: seg, offset: offset, length: len(data), labels: l, fields: f}
+	ev := event{typ: watch.Added, key: key, rv: rv, obj: data, labels: l, fields: f}
+	if prev != nil {
+		s.unindex(prev)
+		ev.typ = watch.Modified
+		ev.prevObj, ev.prevLabels, ev.prevFields = prevData, prev.labels, prev.fields
+	}
+	s.index(e)
+	s.broadcast(ev)
+	return e, nil
+}
+
+// remove deletes an object and notifies the watchers. The deletion is recorded in the tombstone
+// segment, unless it is nil. It must be called with the lock held.
+func (s *store) remove(e *entry, data []byte, tombstones *segment) (uint64, error) {
+	rv := s.rv + 1
+	if tombstones != nil {
+		if _, err := tombstones.append(recordHeader{Op: opDelete, Key: e.key, RV: rv}, nil); err != nil {
+			return 0, err
+		}
+	}
+	s.rv = rv
+	s.unindex(e)
+	s.broadcast(event{typ: watch.Deleted, key: e.key, rv: rv, prevObj: data, prevLabels: e.labels, prevFields: e.fields})
+	return rv, nil
+}
+
+// newestSegment returns the segment with the latest start, or nil if there is none.
+// It must be called with the lock held.
+func (s *store) newestSegment() *segment {
+	var newest *segment
+	for _, seg := range s.segments {
+		if newest == nil || seg.start.After(newest.start) {
+			newest = seg
+		}
+	}
+	return newest
+}
+
+// dropBefore removes all segments that only hold objects older than cutoff. The objects of
+// a dropped segment may have older versions in the segments that are kept, their deletion is
+// recorded in the newest segment so that these versions are not restored on the next load.
+func (s *store) dropBefore(cutoff time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var expired []*segment
+	var tombstones *segment
+	for _, seg := range s.segments {
+		if !seg.start.Add(s.cfg.SegmentDuration).After(cutoff) {
+			expired = append(expired, seg)
+		} else if tombstones == nil || seg.start.After(tombstones.start) {
+			tombstones = seg
+		}
+	}
+	if len(expired) == 0 {
+		return nil
+	}
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].start.Before(expired[j].start)
+	})
+
+	for _, seg := range expired {
+		var keys []string
+		for key, e := range s.entries {
+			if e.seg == seg {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			e := s.entries[key]
+			data, err := seg.read(e.offset, e.length)
+			if err != nil {
+				return err
+			}
+			if _, err := s.remove(e, data, tombstones); err != nil {
+				return err
+			}
+		}
+		// persist the resource version before the records holding it are gone
+		if err := s.saveResourceVersion(); err != nil {
+			return err
+		}
+		delete(s.segments, seg.start.Unix())
+		if err := seg.remove(); err != nil {
+			return err
+		}
+		klog.InfoS("dropped segment", "path", seg.path, "objects", len(keys))
+	}
+	return nil
+}
+
+func (s *store) saveResourceVersion() error {
+	path := filepath.Join(s.cfg.Dir, rvFile)
+	if err := os.WriteFile(path+".tmp", []byte(strconv.FormatUint(s.rv, 10)), 0o600); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+func (s *store) closeSegments() {
+	for _, seg := range s.segments {
+		if err := seg.close(); err != nil {
+			klog.ErrorS(err, "failed to close segment", "path", seg.path)
+		}
+	}
+}
+
+func (s *store) destroy() {
+	s.stopOnce.Do(func() {
+		close(s.stopCh)
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, w := range s.watchers {
+			w.cancel()
+		}
+		if err := s.saveResourceVersion(); err != nil {
+			klog.ErrorS(err, "failed to save resource version", "dir", s.cfg.Dir)
+		}
+		s.closeSegments()
+	})
+}
+
+// readEntry returns the current version of the object stored at key. It must be called with the lock held.
+func (s *store) readEntry(key string) (*entry, []byte, error) {
+	e, ok := s.entries[key]
+	if !ok {
+		return nil, nil, nil
+	}
+	data, err := e.seg.read(e.offset, e.length)
+	if err != nil {
+		return nil, nil, storage.NewInternalError(err)
+	}
+	return e, data, nil
+}
+
+func (s *store) encode(obj runtime.Object) ([]byte, labels.Set, fields.Set, time.Time, error) {
+	if err := s.versioner.PrepareObjectForStorage(obj); err != nil {
+		return nil, nil, nil, time.Time{}, fmt.Errorf("PrepareObjectForStorage failed: %v", err)
+	}
+	data, err := runtime.Encode(s.codec, obj)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+	l, f, err := s.getAttrs(obj)
+	if err != nil {
+		return nil, nil, nil, time.Time{}, err
+	}
+	return data, l, f, s.cfg.TimeFunc(obj), nil
+}
+
+// decode decodes data into objPtr and sets its resource version.
+func (s *store) decode(data []byte, objPtr runtime.Object, rv uint64) error {
+	if _, err := conversion.EnforcePtr(objPtr); err != nil {
+		return fmt.Errorf("unable to convert output object to pointer: %v", err)
+	}
+	if err := runtime.SetZeroValue(objPtr); err != nil {
+		return err
+	}
+	if _, _, err := s.codec.Decode(data, nil, objPtr); err != nil {
+		return storage.NewInternalError(err)
+	}
+	return s.versioner.UpdateObject(objPtr, rv)
+}
+
+func (s *store) decodeObj(data []byte, rv uint64) (runtime.Object, error) {
+	obj := s.newFunc()
+	if err := s.decode(data, obj, rv); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// Versioner implements storage.Interface.
+func (s *store) Versioner() storage.Versioner {
+	return s.versioner
+}
+
+// Create implements storage.Interface.
+func (s *store) Create(ctx context.Context, key string, obj, out runtime.Object, ttl uint64) error {
+	data, l, f, t, err := s.encode(obj)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; ok {
+		return storage.NewKeyExistsError(key, 0)
+	}
+	e, err := s.put(key, data, l, f, t, nil, nil)
+	if err != nil {
+		return err
+	}
+	if out != nil {
+		return s.decode(data, out, e.rv)
+	}
+	return nil
+}
+
+// Delete implements storage.Interface.
+func (s *store) Delete(ctx context.Context, key string, out runtime.Object, preconditions *storage.Preconditions, validateDeletion storage.ValidateObjectFunc, _ runtime.Object, _ storage.DeleteOptions) error {
+	for {
+		s.mu.RLock()
+		e, data, err := s.readEntry(key)
+		s.mu.RUnlock()
+		if err != nil {
+			return err
+		}
+		if e == nil {
+			return storage.NewKeyNotFoundError(key, 0)
+		}
+		if err := s.decode(data, out, e.rv); err != nil {
+			return err
+		}
+		if preconditions != nil {
+			if err := preconditions.Check(key, out); err != nil {
+				return err
+			}
+		}
+		if err := validateDeletion(ctx, out); err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		if s.entries[key] != e {
+			// the object was changed in the meantime, retry with the current version
+			s.mu.Unlock()
+			continue
+		}
+		rv, err := s.remove(e, data, s.newestSegment())
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		return s.versioner.UpdateObject(out, rv)
+	}
+}
+
+// Get implements storage.Interface.
+func (s *store) Get(ctx context.Context, key string, opts storage.GetOptions, objPtr runtime.Object) error {
+	s.mu.RLock()
+	if err := s.checkNotOlderThan(opts.ResourceVersion); err != nil {
+		s.mu.RUnlock()
+		return err
+	}
+	e, data, err := s.readEntry(key)
+	s.mu.RUnlock()
+	if err != nil {
+		return err
+	}
+	if e == nil {
+		if opts.IgnoreNotFound {
+			return runtime.SetZeroValue(objPtr)
+		}
+		return storage.NewKeyNotFoundError(key, 0)
+	}
+	return s.decode(data, objPtr, e.rv)
+}
+
+// checkNotOlderThan returns an error if the requested resource version is newer than the store.
+// It must be called with the lock held.
+func (s *store) checkNotOlderThan(resourceVersion string) error {
+	if resourceVersion == "" {
+		return nil
+	}
+	rv, err := s.versioner.ParseResourceVersion(resourceVersion)
+	if err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("invalid resource version: %v", err))
+	}
+	if rv > s.rv {
+		return storage.NewTooLargeResourceVersionError(rv, s.rv, 1)
+	}
+	return nil
+}
+
+// GetList implements storage.Interface. Lists are always served from the latest state,
+// including the pages of a paginated list. No older states are kept, so a list at an exact
+// resource version other than the latest one fails with 410 Gone, like a list at a compacted
+// revision of etcd.
+func (s *store) GetList(ctx context.Context, key string, opts storage.ListOptions, listObj runtime.Object) error {
+	keyPrefix := key
+	if opts.Recursive && !strings.HasSuffix(keyPrefix, "/") {
+		keyPrefix += "/"
+	}
+	listPtr, err := meta.GetItemsPtr(listObj)
+	if err != nil {
+		return err
+	}
+	v, err := conversion.EnforcePtr(listPtr)
+	if err != nil || v.Kind() != reflect.Slice {
+		return fmt.Errorf("need ptr to slice: %v", err)
+	}
+	withRev, continueKey, err := storage.ValidateListOptions(keyPrefix, s.versioner, opts)
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	switch {
+	case len(opts.Predicate.Continue) > 0:
+	case withRev > 0 && uint64(withRev) > s.rv:
+		return storage.NewTooLargeResourceVersionError(uint64(withRev), s.rv, 1)
+	case withRev > 0 && uint64(withRev) < s.rv:
+		return apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", withRev, s.rv))
+	case opts.ResourceVersionMatch == metav1.ResourceVersionMatchNotOlderThan:
+		if err := s.checkNotOlderThan(opts.ResourceVersion); err != nil {
+			return err
+		}
+	}
+
+	var keys []string
+	if opts.Recursive {
+		for k := range s.candidates(opts.Predicate) {
+			if strings.HasPrefix(k, keyPrefix) && k >= continueKey {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+	} else if _, ok := s.entries[key]; ok {
+		keys = []string{key}
+	}
+
+	if v.IsNil() {
+		v.Set(reflect.MakeSlice(v.Type(), 0, 0))
+	}
+	elem := v.Type().Elem()
+	pred := opts.Predicate
+	var continueValue string
+	var remaining *int64
+	count := int64(0)
+	for i, k := range keys {
+		if pred.Limit > 0 && count == pred.Limit {
+			if continueValue, err = storage.EncodeContinue(keys[i-1]+"\x00", keyPrefix, int64(s.rv)); err != nil {
+				return err
+			}
+			if pred.Empty() {
+				n := int64(len(keys) - i)
+				remaining = &n
+			}
+			break
+		}
+		e := s.entries[k]
+		if !pred.Empty() && !pred.MatchesObjectAttributes(e.labels, e.fields) {
+			continue
+		}
+		data, err := e.seg.read(e.offset, e.length)
+		if err != nil {
+			return storage.NewInternalError(err)
+		}
+		obj := reflect.New(elem).Interface().(runtime.Object)
+		if err := s.decode(data, obj, e.rv); err != nil {
+			return err
+		}
+		v.Set(reflect.Append(v, reflect.ValueOf(obj).Elem()))
+		count++
+	}
+	return s.versioner.UpdateList(listObj, s.rv, continueValue, remaining)
+}
+
+// candidates returns the keys of the objects that may match the predicate, using the
+// indexes for labels and fields the predicate requires an exact match on.
+// It must be called with the lock held.
+func (s *store) candidates(pred storage.SelectionPredicate) sets.Set[string] {
+	var result sets.Set[string]
+	narrow := func(keys sets.Set[string]) {
+		if result == nil {
+			result = keys.Clone()
+		} else {
+			result = result.Intersection(keys)
+		}
+	}
+	if pred.Label != nil {
+		for name, idx := range s.labelIndexes {
+			if value, ok := pred.Label.RequiresExactMatch(name); ok {
+				narrow(idx[value])
+			}
+		}
+	}
+	if pred.Field != nil {
+		for name, idx := range s.fieldIndexes {
+			if value, ok := pred.Field.RequiresExactMatch(name); ok {
+				narrow(idx[value])
+			}
+		}
+	}
+	if result != nil {
+		return result
+	}
+	result = sets.New[string]()
+	for k := range s.entries {
+		result.Insert(k)
+	}
+	return result
+}
+
+// GuaranteedUpdate implements storage.Interface.
+func (s *store) GuaranteedUpdate(ctx context.Context, key string, destination runtime.Object, ignoreNotFound bool, preconditions *storage.Preconditions, tryUpdate storage.UpdateFunc, _ runtime.Object) error {
+	v, err := conversion.EnforcePtr(destination)
+	if err != nil {
+		return fmt.Errorf("unable to convert output object to pointer: %v", err)
+	}
+	for {
+		s.mu.RLock()
+		e, data, err := s.readEntry(key)
+		s.mu.RUnlock()
+		if err != nil {
+			return err
+		}
+
+		cur := reflect.New(v.Type()).Interface().(runtime.Object)
+		var rv uint64
+		if e == nil {
+			if !ignoreNotFound {
+				return storage.NewKeyNotFoundError(key, 0)
+			}
+		} else {
+			rv = e.rv
+			if err := s.decode(data, cur, rv); err != nil {
+				return err
+			}
+		}
+		if preconditions != nil {
+			if err := preconditions.Check(key, cur); err != nil {
+				return err
+			}
+		}
+
+		ret, _, err := tryUpdate(cur, storage.ResponseMeta{ResourceVersion: rv})
+		if err != nil {
+			return err
+		}
+		newData, l, f, t, err := s.encode(ret)
+		if err != nil {
+			return err
+		}
+		if e != nil && bytes.Equal(newData, data) {
+			return s.decode(data, destination, rv)
+		}
+
+		s.mu.Lock()
+		if s.entries[key] != e {
+			s.mu.Unlock()
+			continue
+		}
+		updated, err := s.put(key, newData, l, f, t, e, data)
+		s.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		return s.decode(newData, destination, updated.rv)
+	}
+}
+
+// Stats implements storage.Interface.
+func (s *store) Stats(ctx context.Context) (storage.Stats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	stats := storage.Stats{ObjectCount: int64(len(s.entries))}
+	if stats.ObjectCount > 0 {
+		stats.EstimatedAverageObjectSizeBytes = s.size / stats.ObjectCount
+	}
+	return stats, nil
+}
+
+// ReadinessCheck implements storage.Interface.
+func (s *store) ReadinessCheck() error {
+	return nil
+}
+
+// RequestWatchProgress implements storage.Interface.
+func (s *store) RequestWatchProgress(ctx context.Context) error {
+	return nil
+}
+
+// GetCurrentResourceVersion implements storage.Interface.
+func (s *store) GetCurrentResourceVersion(ctx context.Context) (uint64, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.rv, nil
+}
+
+// SetKeysFunc implements storage.Interface. Keys are always served from the in-memory index.
+func (s *store) SetKeysFunc(storage.KeysFunc) {
+}
+
+// CompactRevision implements storage.Interface.
+func (s *store) CompactRevision() int64 {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return int64(s.compacted)
+}
@@ -0,0 +1,272 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/apis/falco/install"
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcotest"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/serializer"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+)
+
+const prefix = "/falco.appscode.com/falcoevents"
+
+func getAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
+	fe := obj.(*api.FalcoEvent)
+	return fe.Labels, fields.Set{"metadata.name": fe.Name, "spec.rule": fe.Spec.Rule}, nil
+}
+
+func newTestStore(t *testing.T, dir string) *store {
+	scheme := runtime.NewScheme()
+	install.Install(scheme)
+	codec := serializer.NewCodecFactory(scheme).LegacyCodec(v1beta1.SchemeGroupVersion)
+
+	cfg := Config{
+		Dir: dir,
+		TimeFunc: func(obj runtime.Object) time.Time {
+			return obj.(*api.FalcoEvent).Spec.Time.Time
+		},
+		LabelIndexes: []string{api.LabelNamespaceName},
+		FieldIndexes: []string{"spec.rule"},
+	}
+	s, err := newStore(cfg, codec, prefix,
+		func() runtime.Object { return &api.FalcoEvent{} },
+		func() runtime.Object { return &api.FalcoEventList{} },
+		getAttrs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func list(t *testing.T, s *store, pred storage.SelectionPredicate) *api.FalcoEventList {
+	var out api.FalcoEventList
+	if err := s.GetList(context.TODO(), prefix, storage.ListOptions{Predicate: pred, Recursive: true}, &out); err != nil {
+		t.Fatal(err)
+	}
+	return &out
+}
+
+func TestStore(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+	s := newTestStore(t, dir)
+
+	now := time.Now().UTC()
+	old := now.Add(-3 * time.Hour)
+	for _, fe := range []*api.FalcoEvent{
+		falcotest.NewEvent("fe-1", "shell", api.PriorityNotice, now, falcotest.InNamespace("default")),
+		falcotest.NewEvent("fe-2", "shell", api.PriorityNotice, now, falcotest.InNamespace("kube-system")),
+		falcotest.NewEvent("fe-3", "write-etc", api.PriorityNotice, old, falcotest.InNamespace("default")),
+	} {
+		var out api.FalcoEvent
+		if err := s.Create(ctx, prefix+"/"+fe.Name, fe, &out, 0); err != nil {
+			t.Fatal(err)
+		}
+		if out.ResourceVersion == "" {
+			t.Fatal("created object has no resource version")
+		}
+	}
+	if err := s.Create(ctx, prefix+"/fe-1", falcotest.NewEvent("fe-1", "shell", api.PriorityNotice, now, falcotest.InNamespace("default")), nil, 0); !storage.IsExist(err) {
+		t.Fatalf("Create() of existing object error = %v", err)
+	}
+
+	pred := storage.SelectionPredicate{
+		Label:    labels.SelectorFromSet(labels.Set{api.LabelNamespaceName: "default"}),
+		Field:    fields.OneTermEqualSelector("spec.rule", "shell"),
+		GetAttrs: getAttrs,
+	}
+	if got := list(t, s, pred); len(got.Items) != 1 || got.Items[0].Name != "fe-1" {
+		t.Fatalf("GetList() = %v, want fe-1", got.Items)
+	}
+
+	page := storage.Everything
+	page.Limit = 2
+	first := list(t, s, page)
+	if len(first.Items) != 2 || first.Continue == "" || first.RemainingItemCount == nil || *first.RemainingItemCount != 1 {
+		t.Fatalf("GetList() with limit = %d items, continue %q", len(first.Items), first.Continue)
+	}
+	page.Continue = first.Continue
+	if second := list(t, s, page); len(second.Items) != 1 || second.Items[0].Name != "fe-3" {
+		t.Fatalf("GetList() of second page = %v, want fe-3", second.Items)
+	}
+
+	w, err := s.Watch(ctx, prefix, storage.ListOptions{ResourceVersion: first.ResourceVersion, Predicate: storage.Everything, Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+
+	var updated api.FalcoEvent
+	err = s.GuaranteedUpdate(ctx, prefix+"/fe-1", &updated, false, nil, func(input runtime.Object, _ storage.ResponseMeta) (runtime.Object, *uint64, error) {
+		fe := input.(*api.FalcoEvent)
+		fe.Status.Count++
+		return fe, nil, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Status.Count != 1 {
+		t.Fatalf("GuaranteedUpdate() count = %d, want 1", updated.Status.Count)
+	}
+	expectEvent(t, w, watch.Modified, "fe-1")
+
+	var deleted api.FalcoEvent
+	if err := s.Delete(ctx, prefix+"/fe-2", &deleted, nil, storage.ValidateAllObjectFunc, nil, storage.DeleteOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, w, watch.Deleted, "fe-2")
+
+	if err := s.dropBefore(now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, w, watch.Deleted, "fe-3")
+
+	rv, _ := s.GetCurrentResourceVersion(ctx)
+	s.destroy()
+
+	// the state is rebuilt from the segments left on disk
+	s = newTestStore(t, dir)
+	defer s.destroy()
+	if got, _ := s.GetCurrentResourceVersion(ctx); got != rv {
+		t.Fatalf("resource version after restart = %d, want %d", got, rv)
+	}
+	var fe api.FalcoEvent
+	if err := s.Get(ctx, prefix+"/fe-1", storage.GetOptions{}, &fe); err != nil {
+		t.Fatal(err)
+	}
+	if fe.Status.Count != 1 || fe.ResourceVersion != updated.ResourceVersion {
+		t.Fatalf("Get() after restart = %d at %s, want 1 at %s", fe.Status.Count, fe.ResourceVersion, updated.ResourceVersion)
+	}
+	if got := list(t, s, storage.Everything); len(got.Items) != 1 {
+		t.Fatalf("GetList() after restart = %d items, want 1", len(got.Items))
+	}
+}
+
+func TestDropMovedObject(t *testing.T) {
+	ctx := context.TODO()
+	dir := t.TempDir()
+	s := newTestStore(t, dir)
+
+	now := time.Now().UTC()
+	if err := s.Create(ctx, prefix+"/fe-1", falcotest.NewEvent("fe-1", "shell", api.PriorityNotice, now, falcotest.InNamespace("default")), nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	// the new version is written to an older segment than the one holding the created object
+	err := s.GuaranteedUpdate(ctx, prefix+"/fe-1", &api.FalcoEvent{}, false, nil, func(input runtime.Object, _ storage.ResponseMeta) (runtime.Object, *uint64, error) {
+		fe := input.(*api.FalcoEvent)
+		fe.Spec.Time = metav1.NewMicroTime(now.Add(-3 * time.Hour))
+		return fe, nil, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.dropBefore(now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	var out api.FalcoEventList
+	err = s.GetList(ctx, prefix, storage.ListOptions{ResourceVersion: "1", ResourceVersionMatch: metav1.ResourceVersionMatchExact, Predicate: storage.Everything, Recursive: true}, &out)
+	if !apierrors.IsResourceExpired(err) {
+		t.Fatalf("GetList() at an old resource version error = %v, want expired", err)
+	}
+	s.destroy()
+
+	// the created object must not be restored from the segment that was kept
+	s = newTestStore(t, dir)
+	defer s.destroy()
+	if got := list(t, s, storage.Everything); len(got.Items) != 0 {
+		t.Fatalf("GetList() after restart = %v, want no objects", got.Items)
+	}
+}
+
+func TestWatchInitialEvents(t *testing.T) {
+	ctx := context.TODO()
+	s := newTestStore(t, t.TempDir())
+	defer s.destroy()
+
+	now := time.Now().UTC()
+	for _, fe := range []*api.FalcoEvent{
+		falcotest.NewEvent("fe-1", "shell", api.PriorityNotice, now, falcotest.InNamespace("default")),
+		falcotest.NewEvent("fe-2", "shell", api.PriorityNotice, now.Add(-3*time.Hour), falcotest.InNamespace("default")),
+	} {
+		if err := s.Create(ctx, prefix+"/"+fe.Name, fe, nil, 0); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	w, err := s.Watch(ctx, prefix, storage.ListOptions{Predicate: storage.Everything, Recursive: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer w.Stop()
+	expectEvent(t, w, watch.Added, "fe-1")
+	expectEvent(t, w, watch.Added, "fe-2")
+
+	// the objects of a snapshot may change before they are read
+	s.mu.RLock()
+	rv := s.rv
+	s.mu.RUnlock()
+	var updated api.FalcoEvent
+	err = s.GuaranteedUpdate(ctx, prefix+"/fe-1", &updated, false, nil, func(input runtime.Object, _ storage.ResponseMeta) (runtime.Object, *uint64, error) {
+		fe := input.(*api.FalcoEvent)
+		fe.Status.Count++
+		return fe, nil, nil
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.dropBefore(now.Add(-time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	expectEvent(t, w, watch.Modified, "fe-1")
+	expectEvent(t, w, watch.Deleted, "fe-2")
+
+	s.mu.RLock()
+	changes := s.changesSince(&watcher{key: prefix + "/", recursive: true}, rv, sets.New(prefix+"/fe-1", prefix+"/fe-2"))
+	s.mu.RUnlock()
+	if len(changes) != 1 || changes[0].typ != watch.Added || changes[0].key != prefix+"/fe-1" || changes[0].prevObj != nil {
+		t.Fatalf("changesSince() = %v, want the creation of fe-1", changes)
+	}
+}
+
+func expectEvent(t *testing.T, w watch.Interface, typ watch.EventType, name string) {
+	t.Helper()
+	select {
+	case ev := <-w.ResultChan():
+		fe, ok := ev.Object.(*api.FalcoEvent)
+		if ev.Type != typ || !ok || fe.Name != name {
+			t.Fatalf("got %s event for %v, want %s for %s", ev.Type, ev.Object, typ, name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("timed out waiting for %s event for %s", typ, name)
+	}
+}
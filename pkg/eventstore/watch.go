@@ -0,0 +1,336 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/storage"
+	utilflowcontrol "k8s.io/apiserver/pkg/util/flowcontrol"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// watcherBufSize is the number of changes a watcher may lag behind before it is closed.
+	watcherBufSize = 1024
+	// maxSnapshotAttempts is the number of times the initial events of a watch are read
+	// before giving up, when more changes are made meanwhile than the history keeps.
+	maxSnapshotAttempts = 3
+)
+
+// event is a change to the store. obj is empty for deletions, prevObj is empty for creations.
+type event struct {
+	typ        watch.EventType
+	key        string
+	rv         uint64
+	obj        []byte
+	labels     labels.Set
+	fields     fields.Set
+	prevObj    []byte
+	prevLabels labels.Set
+	prevFields fields.Set
+}
+
+type watcher struct {
+	s         *store
+	id        int
+	key       string
+	recursive bool
+	pred      storage.SelectionPredicate
+
+	incoming chan event
+	result   chan watch.Event
+	ctx      context.Context
+	cancel   context.CancelFunc
+}
+
+var _ watch.Interface = &watcher{}
+
+// broadcast records a change in the watch history and passes it on to the watchers.
+// It must be called with the lock held.
+func (s *store) broadcast(ev event) {
+	s.history = append(s.history, ev)
+	if n := len(s.history) - s.cfg.WatchHistory; n > 0 {
+		s.compacted = s.history[n-1].rv
+		s.history = append(s.history[:0:0], s.history[n:]...)
+	}
+
+	for id, w := range s.watchers {
+		if !w.interested(ev.key) {
+			continue
+		}
+		select {
+		case w.incoming <- ev:
+		default:
+			klog.V(3).InfoS("closing unresponsive watcher", "key", w.key)
+			delete(s.watchers, id)
+			w.cancel()
+		}
+	}
+}
+
+// Watch implements storage.Interface. Watches are served from the changes kept in memory,
+// a watch starting at a resource version that is no longer kept fails with 410 Gone.
+func (s *store) Watch(ctx context.Context, key string, opts storage.ListOptions) (watch.Interface, error) {
+	rv, err := s.versioner.ParseResourceVersion(opts.ResourceVersion)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid resource version: %v", err))
+	}
+	if opts.Recursive && !strings.HasSuffix(key, "/") {
+		key += "/"
+	}
+	sendInitialEvents := opts.SendInitialEvents != nil && *opts.SendInitialEvents
+	initialEvents := sendInitialEvents || (opts.SendInitialEvents == nil && rv == 0)
+
+	s.mu.Lock()
+	if rv > s.rv {
+		s.mu.Unlock()
+		return nil, storage.NewTooLargeResourceVersionError(rv, s.rv, 1)
+	}
+	if !initialEvents && rv > 0 && rv < s.compacted {
+		s.mu.Unlock()
+		return nil, apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", rv, s.compacted))
+	}
+
+	w := &watcher{
+		s:         s,
+		key:       key,
+		recursive: opts.Recursive,
+		pred:      opts.Predicate,
+		incoming:  make(chan event, watcherBufSize),
+		result:    make(chan watch.Event),
+	}
+	w.ctx, w.cancel = context.WithCancel(ctx)
+
+	var backlog []event
+	if initialEvents {
+		s.mu.Unlock()
+		if backlog, err = s.snapshot(w); err != nil {
+			w.cancel()
+			return nil, err
+		}
+	} else if rv > 0 {
+		backlog = s.changesSince(w, rv, nil)
+	}
+	startRV := s.rv
+	s.watcherID++
+	w.id = s.watcherID
+	s.watchers[w.id] = w
+	s.mu.Unlock()
+
+	go w.run(backlog, sendInitialEvents && opts.Predicate.AllowWatchBookmarks, startRV)
+	utilflowcontrol.WatchInitialized(ctx)
+	return w, nil
+}
+
+// snapshot returns the current objects watched by w as creations, followed by the changes
+// made while they were read. The objects are read without the lock held, so a large initial
+// list does not block writes. It must be called without the lock held and returns with the
+// lock held, unless it fails.
+func (s *store) snapshot(w *watcher) ([]event, error) {
+	for i := 0; i < maxSnapshotAttempts; i++ {
+		s.mu.RLock()
+		rv := s.rv
+		entries := s.watchedEntries(w)
+		s.mu.RUnlock()
+
+		events := make([]event, 0, len(entries))
+		missing := sets.New[string]()
+		for _, e := range entries {
+			data, err := e.seg.read(e.offset, e.length)
+			if err != nil {
+				if !s.dropped(e.seg) {
+					return nil, storage.NewInternalError(err)
+				}
+				// the segment expired meanwhile, the deletion is part of the replayed changes
+				missing.Insert(e.key)
+				continue
+			}
+			events = append(events, event{typ: watch.Added, key: e.key, rv: e.rv, obj: data, labels: e.labels, fields: e.fields})
+		}
+
+		s.mu.Lock()
+		if rv >= s.compacted {
+			return append(events, s.changesSince(w, rv, missing)...), nil
+		}
+		s.mu.Unlock()
+	}
+	return nil, apierrors.NewResourceExpired("too many changes while reading the initial events")
+}
+
+// watchedEntries returns a copy of the entries watched by w. It must be called with the lock held.
+func (s *store) watchedEntries(w *watcher) []entry {
+	var keys []string
+	if w.recursive {
+		for k := range s.candidates(w.pred) {
+			if strings.HasPrefix(k, w.key) {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+	} else if _, ok := s.entries[w.key]; ok {
+		keys = []string{w.key}
+	}
+
+	entries := make([]entry, 0, len(keys))
+	for _, k := range keys {
+		entries = append(entries, *s.entries[k])
+	}
+	return entries
+}
+
+// dropped returns true if the segment was removed from the store.
+func (s *store) dropped(seg *segment) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.segments[seg.start.Unix()] != seg
+}
+
+// changesSince returns the changes after rv watched by w. The objects of the keys in missing
+// were not sent to w, so their first change is sent as a creation, or skipped for a deletion.
+// It must be called with the lock held.
+func (s *store) changesSince(w *watcher, rv uint64, missing sets.Set[string]) []event {
+	var events []event
+	i := sort.Search(len(s.history), func(i int) bool { return s.history[i].rv > rv })
+	for _, ev := range s.history[i:] {
+		if !w.interested(ev.key) {
+			continue
+		}
+		if missing.Has(ev.key) {
+			missing.Delete(ev.key)
+			if ev.obj == nil {
+				continue
+			}
+			ev.typ, ev.prevObj, ev.prevLabels, ev.prevFields = watch.Added, nil, nil, nil
+		}
+		events = append(events, ev)
+	}
+	return events
+}
+
+func (w *watcher) interested(key string) bool {
+	if w.recursive {
+		return strings.HasPrefix(key, w.key)
+	}
+	return key == w.key
+}
+
+func (w *watcher) matches(data []byte, l labels.Set, f fields.Set) bool {
+	return data != nil && (w.pred.Empty() || w.pred.MatchesObjectAttributes(l, f))
+}
+
+func (w *watcher) run(backlog []event, initialEventsEndBookmark bool, startRV uint64) {
+	defer close(w.result)
+	defer w.s.removeWatcher(w.id)
+	defer w.cancel()
+
+	for _, ev := range backlog {
+		if !w.send(ev) {
+			return
+		}
+	}
+	if initialEventsEndBookmark {
+		obj := w.s.newFunc()
+		if err := w.s.versioner.UpdateObject(obj, startRV); err != nil {
+			w.sendError(err)
+			return
+		}
+		if m, err := meta.Accessor(obj); err == nil {
+			m.SetAnnotations(map[string]string{metav1.InitialEventsAnnotationKey: "true"})
+		}
+		if !w.emit(watch.Event{Type: watch.Bookmark, Object: obj}) {
+			return
+		}
+	}
+
+	for {
+		select {
+		case ev := <-w.incoming:
+			if !w.send(ev) {
+				return
+			}
+		case <-w.ctx.Done():
+			return
+		}
+	}
+}
+
+// send converts a change into the watch event seen by the watcher's predicate.
+func (w *watcher) send(ev event) bool {
+	curMatch := w.matches(ev.obj, ev.labels, ev.fields)
+	prevMatch := w.matches(ev.prevObj, ev.prevLabels, ev.prevFields)
+
+	var typ watch.EventType
+	data := ev.obj
+	switch {
+	case curMatch && prevMatch:
+		typ = watch.Modified
+	case curMatch:
+		typ = watch.Added
+	case prevMatch:
+		typ = watch.Deleted
+		data = ev.prevObj
+	default:
+		return true
+	}
+
+	obj, err := w.s.decodeObj(data, ev.rv)
+	if err != nil {
+		w.sendError(err)
+		return false
+	}
+	return w.emit(watch.Event{Type: typ, Object: obj})
+}
+
+func (w *watcher) sendError(err error) {
+	w.emit(watch.Event{Type: watch.Error, Object: &apierrors.NewInternalError(err).ErrStatus})
+}
+
+func (w *watcher) emit(ev watch.Event) bool {
+	select {
+	case w.result <- ev:
+		return true
+	case <-w.ctx.Done():
+		return false
+	}
+}
+
+func (s *store) removeWatcher(id int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.watchers, id)
+}
+
+// Stop implements watch.Interface.
+func (w *watcher) Stop() {
+	w.cancel()
+}
+
+// ResultChan implements watch.Interface.
+func (w *watcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
@@ -0,0 +1,257 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// Export formats.
+const (
+	FormatNDJSON = "ndjson"
+	FormatCSV    = "csv"
+	FormatSARIF  = "sarif"
+)
+
+var contentTypes = map[string]string{
+	FormatNDJSON: "application/x-ndjson",
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatSARIF:  "application/sarif+json",
+}
+
+// pageSize is the number of FalcoEvents read from the storage at a time.
+const pageSize = 500
+
+// Options selects the FalcoEvents to export and how they are written.
+type Options struct {
+	Format        string
+	LabelSelector labels.Selector
+	FieldSelector fields.Selector
+	// Since and Until bound the event time. Since is inclusive, Until is exclusive.
+	Since, Until time.Time
+	// Columns are the CSV columns, see DefaultColumns.
+	Columns []string
+}
+
+func (o Options) matches(fe *api.FalcoEvent) bool {
+	t := fe.Spec.Time.Time
+	if !o.Since.IsZero() && t.Before(o.Since) {
+		return false
+	}
+	if !o.Until.IsZero() && !t.Before(o.Until) {
+		return false
+	}
+	return true
+}
+
+// encoder writes FalcoEvents in one export format.
+type encoder interface {
+	begin() error
+	encode(fe *api.FalcoEvent) error
+	// flush writes out the buffered output, it is called after every page
+	flush() error
+	end() error
+}
+
+func newEncoder(opts Options, w io.Writer) (encoder, error) {
+	switch opts.Format {
+	case FormatNDJSON:
+		return newNDJSONEncoder(w), nil
+	case FormatCSV:
+		return newCSVEncoder(w, opts.Columns), nil
+	case FormatSARIF:
+		return newSARIFEncoder(w), nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", opts.Format)
+	}
+}
+
+// ParseOptions reads the export options from the query parameters format, labelSelector,
+// fieldSelector, since, until and columns. Times are RFC 3339.
+func ParseOptions(q map[string][]string) (Options, error) {
+	get := func(k string) string {
+		if v := q[k]; len(v) > 0 {
+			return v[0]
+		}
+		return ""
+	}
+
+	opts := Options{
+		Format:        FormatNDJSON,
+		LabelSelector: labels.Everything(),
+		FieldSelector: fields.Everything(),
+	}
+	if s := get("format"); s != "" {
+		if _, ok := contentTypes[s]; !ok {
+			return opts, fmt.Errorf("unknown export format %q", s)
+		}
+		opts.Format = s
+	}
+	var err error
+	if s := get("labelSelector"); s != "" {
+		if opts.LabelSelector, err = labels.Parse(s); err != nil {
+			return opts, err
+		}
+	}
+	if s := get("fieldSelector"); s != "" {
+		if opts.FieldSelector, err = fields.ParseSelector(s); err != nil {
+			return opts, err
+		}
+	}
+	if s := get("since"); s != "" {
+		if opts.Since, err = time.Parse(time.RFC3339, s); err != nil {
+			return opts, fmt.Errorf("invalid since: %v", err)
+		}
+	}
+	if s := get("until"); s != "" {
+		if opts.Until, err = time.Parse(time.RFC3339, s); err != nil {
+			return opts, fmt.Errorf("invalid until: %v", err)
+		}
+	}
+	if s := get("columns"); s != "" {
+		for _, c := range strings.Split(s, ",") {
+			if c = strings.TrimSpace(c); c != "" {
+				opts.Columns = append(opts.Columns, c)
+			}
+		}
+	}
+	return opts, nil
+}
+
+// Export writes the FalcoEvents matching the options to w. Events are read from the storage
+// one page at a time, so the full list is never held in memory.
+func Export(ctx context.Context, store rest.Lister, opts Options, w io.Writer) error {
+	enc, err := newEncoder(opts, w)
+	if err != nil {
+		return err
+	}
+	return export(ctx, store, opts, enc, nil)
+}
+
+func export(ctx context.Context, store rest.Lister, opts Options, enc encoder, flush func()) error {
+	ctx = genericapirequest.WithNamespace(ctx, metav1.NamespaceNone)
+	listOpts := &metainternalversion.ListOptions{
+		LabelSelector: opts.LabelSelector,
+		FieldSelector: opts.FieldSelector,
+		Limit:         pageSize,
+	}
+
+	list, err := listPage(ctx, store, listOpts)
+	if err != nil {
+		return err
+	}
+	if err := enc.begin(); err != nil {
+		return err
+	}
+	for {
+		for i := range list.Items {
+			if !opts.matches(&list.Items[i]) {
+				continue
+			}
+			if err := enc.encode(&list.Items[i]); err != nil {
+				return err
+			}
+		}
+		if err := enc.flush(); err != nil {
+			return err
+		}
+		if flush != nil {
+			flush()
+		}
+		if list.Continue == "" {
+			break
+		}
+		listOpts.Continue = list.Continue
+		if list, err = listPage(ctx, store, listOpts); err != nil {
+			return err
+		}
+	}
+	return enc.end()
+}
+
+func listPage(ctx context.Context, store rest.Lister, opts *metainternalversion.ListOptions) (*api.FalcoEventList, error) {
+	obj, err := store.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	list, ok := obj.(*api.FalcoEventList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected list type %T", obj)
+	}
+	return list, nil
+}
+
+// Handler streams the FalcoEvents matching the query parameters, see ParseOptions.
+func Handler(store rest.Lister) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Please send with get http method", http.StatusMethodNotAllowed)
+			return
+		}
+		opts, err := ParseOptions(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", contentTypes[opts.Format])
+		// the status is only sent with the first page, so a failing list can still be reported
+		hw := &statusWriter{w: w}
+		enc, err := newEncoder(opts, hw)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		flush := func() {
+			if f, ok := w.(http.Flusher); ok && hw.written {
+				f.Flush()
+			}
+		}
+		if err := export(r.Context(), store, opts, enc, flush); err != nil {
+			if !hw.written {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+			klog.ErrorS(err, "failed to export FalcoEvents")
+		}
+	})
+}
+
+type statusWriter struct {
+	w       http.ResponseWriter
+	written bool
+}
+
+func (s *statusWriter) Write(p []byte) (int, error) {
+	s.written = true
+	return s.w.Write(p)
+}
@@ -0,0 +1,214 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/pkg/falcotest"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+// fakeLister serves the events in pages of two, honoring the label selector.
+type fakeLister struct {
+	rest.TableConvertor
+	events []api.FalcoEvent
+	pages  int
+}
+
+func (f *fakeLister) NewList() runtime.Object { return &api.FalcoEventList{} }
+
+func (f *fakeLister) List(_ context.Context, opts *metainternalversion.ListOptions) (runtime.Object, error) {
+	f.pages++
+	start := 0
+	if opts.Continue != "" {
+		start, _ = strconv.Atoi(opts.Continue)
+	}
+	list := &api.FalcoEventList{}
+	end := min(start+2, len(f.events))
+	for _, fe := range f.events[start:end] {
+		if opts.LabelSelector.Matches(labels.Set(fe.Labels)) {
+			list.Items = append(list.Items, fe)
+		}
+	}
+	if end < len(f.events) {
+		list.Continue = strconv.Itoa(end)
+	}
+	return list, nil
+}
+
+func TestExport(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	fields := falcotest.WithOutputFields(`{"proc.name":"bash","proc.pid":42}`)
+	events := []api.FalcoEvent{
+		*falcotest.NewEvent("a", "Terminal shell", api.PriorityWarning, t0, falcotest.OnPod("demo", "a"), fields),
+		*falcotest.NewEvent("b", "Write below etc", api.PriorityError, t0.Add(time.Hour), falcotest.OnPod("demo", "b"), fields),
+		*falcotest.NewEvent("c", "Terminal shell", api.PriorityNotice, t0.Add(2*time.Hour), fields),
+		*falcotest.NewEvent("d", "Terminal shell", api.PriorityWarning, t0.Add(3*time.Hour), falcotest.OnPod("other", "d"), fields),
+		*falcotest.NewEvent("e", "Write below etc", api.PriorityCritical, t0.Add(4*time.Hour), falcotest.OnPod("demo", "e"), fields),
+	}
+
+	export := func(t *testing.T, q string) (string, *fakeLister) {
+		opts, err := ParseOptions(queryOf(q))
+		if err != nil {
+			t.Fatal(err)
+		}
+		store := &fakeLister{events: events}
+		var buf bytes.Buffer
+		if err := Export(context.TODO(), store, opts, &buf); err != nil {
+			t.Fatal(err)
+		}
+		return buf.String(), store
+	}
+
+	t.Run("ndjson", func(t *testing.T) {
+		out, store := export(t, "labelSelector=k8s.ns.name%3Ddemo&since=2024-01-01T01:00:00Z")
+		if store.pages != 3 {
+			t.Errorf("read %d pages, want 3", store.pages)
+		}
+		lines := strings.Split(strings.TrimSpace(out), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("got %d lines, want 2:\n%s", len(lines), out)
+		}
+		var p map[string]any
+		if err := json.Unmarshal([]byte(lines[0]), &p); err != nil {
+			t.Fatal(err)
+		}
+		if p["rule"] != "Write below etc" || p["priority"] != "Error" || p["time"] != "2024-01-01T01:00:00Z" {
+			t.Errorf("unexpected payload %s", lines[0])
+		}
+		if f, _ := p["output_fields"].(map[string]any); f["proc.pid"] != float64(42) {
+			t.Errorf("unexpected output fields %s", lines[0])
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		out, _ := export(t, "format=csv&columns=name,priority,proc.pid&until=2024-01-01T02:00:00Z")
+		rows, err := csv.NewReader(strings.NewReader(out)).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := [][]string{{"name", "priority", "proc.pid"}, {"a", "Warning", "42"}, {"b", "Error", "42"}}
+		if len(rows) != len(want) {
+			t.Fatalf("got %v, want %v", rows, want)
+		}
+		for i := range want {
+			if strings.Join(rows[i], ",") != strings.Join(want[i], ",") {
+				t.Errorf("row %d = %v, want %v", i, rows[i], want[i])
+			}
+		}
+	})
+
+	t.Run("csv formulas", func(t *testing.T) {
+		fe := *falcotest.NewEvent("f", "Terminal shell", api.PriorityWarning, t0, falcotest.OnPod("demo", "f"), fields)
+		fe.Spec.Output = "=HYPERLINK(\"http://attacker\")"
+		fe.Spec.OutputFields.Raw = []byte(`{"proc.cmdline":"=cmd|' /C calc'!A0","proc.pid":-1,"user.name":"@admin"}`)
+		opts, err := ParseOptions(queryOf("format=csv&columns=output,proc.cmdline,proc.pid,user.name,%2Bcol"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var buf bytes.Buffer
+		if err := Export(context.TODO(), &fakeLister{events: []api.FalcoEvent{fe}}, opts, &buf); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(&buf).ReadAll()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := [][]string{
+			{"output", "proc.cmdline", "proc.pid", "user.name", "'+col"},
+			{`'=HYPERLINK("http://attacker")`, "'=cmd|' /C calc'!A0", "'-1", "'@admin", ""},
+		}
+		for i := range want {
+			if i >= len(rows) || strings.Join(rows[i], ",") != strings.Join(want[i], ",") {
+				t.Errorf("got rows %q, want %q", rows, want)
+				break
+			}
+		}
+	})
+
+	t.Run("sarif", func(t *testing.T) {
+		out, _ := export(t, "format=sarif")
+		var log struct {
+			Version string `json:"version"`
+			Runs    []struct {
+				Tool struct {
+					Driver struct {
+						Rules []sarifRule `json:"rules"`
+					} `json:"driver"`
+				} `json:"tool"`
+				Results []sarifResult `json:"results"`
+			} `json:"runs"`
+		}
+		if err := json.Unmarshal([]byte(out), &log); err != nil {
+			t.Fatalf("invalid sarif: %v\n%s", err, out)
+		}
+		if log.Version != "2.1.0" || len(log.Runs) != 1 {
+			t.Fatalf("unexpected sarif log %s", out)
+		}
+		run := log.Runs[0]
+		if len(run.Tool.Driver.Rules) != 2 || len(run.Results) != 5 {
+			t.Fatalf("got %d rules and %d results, want 2 and 5", len(run.Tool.Driver.Rules), len(run.Results))
+		}
+		for _, r := range run.Results {
+			if run.Tool.Driver.Rules[r.RuleIndex].ID != r.RuleID {
+				t.Errorf("result of rule %s refers to rule %d", r.RuleID, r.RuleIndex)
+			}
+		}
+		if run.Results[1].Level != "error" || run.Results[2].Level != "note" || run.Results[2].Locations != nil {
+			t.Errorf("unexpected results %+v", run.Results)
+		}
+	})
+}
+
+func TestParseOptions(t *testing.T) {
+	if _, err := ParseOptions(queryOf("format=xml")); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+	if _, err := ParseOptions(queryOf("since=yesterday")); err == nil {
+		t.Error("expected an error for an invalid time")
+	}
+	opts, err := ParseOptions(queryOf("fieldSelector=spec.rule%3Dx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Format != FormatNDJSON || !opts.FieldSelector.Matches(fields.Set{"spec.rule": "x"}) {
+		t.Errorf("unexpected options %+v", opts)
+	}
+}
+
+func queryOf(s string) map[string][]string {
+	q, err := url.ParseQuery(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
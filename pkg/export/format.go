@@ -0,0 +1,182 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+)
+
+// falcoPayload is the JSON a FalcoEvent was created from, as sent by Falco.
+type falcoPayload struct {
+	UUID         string          `json:"uuid,omitempty"`
+	Output       string          `json:"output"`
+	Priority     api.Priority    `json:"priority"`
+	Rule         string          `json:"rule"`
+	Time         time.Time       `json:"time"`
+	OutputFields json.RawMessage `json:"output_fields"`
+	Source       string          `json:"source"`
+	Tags         []string        `json:"tags,omitempty"`
+	Hostname     string          `json:"hostname,omitempty"`
+}
+
+func toFalcoPayload(fe *api.FalcoEvent) falcoPayload {
+	fields := json.RawMessage(fe.Spec.OutputFields.Raw)
+	if len(fields) == 0 {
+		fields = json.RawMessage("{}")
+	}
+	return falcoPayload{
+		UUID:         fe.Spec.UUID,
+		Output:       fe.Spec.Output,
+		Priority:     fe.Spec.Priority,
+		Rule:         fe.Spec.Rule,
+		Time:         fe.Spec.Time.UTC(),
+		OutputFields: fields,
+		Source:       fe.Spec.Source,
+		Tags:         fe.Spec.Tags,
+		Hostname:     fe.Spec.Hostname,
+	}
+}
+
+// ndjsonEncoder writes one Falco JSON payload per line.
+type ndjsonEncoder struct {
+	w   *bufio.Writer
+	enc *json.Encoder
+}
+
+func newNDJSONEncoder(w io.Writer) *ndjsonEncoder {
+	bw := bufio.NewWriter(w)
+	return &ndjsonEncoder{w: bw, enc: json.NewEncoder(bw)}
+}
+
+func (e *ndjsonEncoder) begin() error { return nil }
+
+func (e *ndjsonEncoder) encode(fe *api.FalcoEvent) error {
+	return e.enc.Encode(toFalcoPayload(fe))
+}
+
+func (e *ndjsonEncoder) flush() error { return e.w.Flush() }
+
+func (e *ndjsonEncoder) end() error { return e.w.Flush() }
+
+// DefaultColumns are the CSV columns used if none are requested.
+var DefaultColumns = []string{"time", "priority", "rule", "namespace", "pod", "output"}
+
+// columnFuncs are the CSV columns taken from the FalcoEvent. Any other column is read from
+// the output field of the same name, eg, proc.cmdline.
+var columnFuncs = map[string]func(fe *api.FalcoEvent) string{
+	"name":      func(fe *api.FalcoEvent) string { return fe.Name },
+	"uuid":      func(fe *api.FalcoEvent) string { return fe.Spec.UUID },
+	"time":      func(fe *api.FalcoEvent) string { return fe.Spec.Time.UTC().Format(time.RFC3339Nano) },
+	"priority":  func(fe *api.FalcoEvent) string { return string(fe.Spec.Priority) },
+	"rule":      func(fe *api.FalcoEvent) string { return fe.Spec.Rule },
+	"source":    func(fe *api.FalcoEvent) string { return fe.Spec.Source },
+	"tags":      func(fe *api.FalcoEvent) string { return strings.Join(fe.Spec.Tags, ";") },
+	"hostname":  func(fe *api.FalcoEvent) string { return fe.Spec.Hostname },
+	"node":      func(fe *api.FalcoEvent) string { return fe.Spec.Nodename },
+	"namespace": func(fe *api.FalcoEvent) string { return fe.Labels[api.FieldNamespaceName] },
+	"pod":       func(fe *api.FalcoEvent) string { return fe.Labels[api.FieldPodName] },
+	"output":    func(fe *api.FalcoEvent) string { return fe.Spec.Output },
+	"count":     func(fe *api.FalcoEvent) string { return strconv.FormatInt(fe.Status.Count, 10) },
+	"triage":    func(fe *api.FalcoEvent) string { return string(fe.Status.TriageState) },
+}
+
+// csvEncoder writes a header row with the column names, followed by one row per event.
+type csvEncoder struct {
+	w       *csv.Writer
+	columns []string
+	// outputFields is true if any column is read from the output fields
+	outputFields bool
+}
+
+func newCSVEncoder(w io.Writer, columns []string) *csvEncoder {
+	if len(columns) == 0 {
+		columns = DefaultColumns
+	}
+	e := &csvEncoder{w: csv.NewWriter(w), columns: columns}
+	for _, c := range columns {
+		if _, ok := columnFuncs[c]; !ok {
+			e.outputFields = true
+		}
+	}
+	return e
+}
+
+func (e *csvEncoder) begin() error {
+	row := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		row[i] = neutralizeCell(c)
+	}
+	return e.w.Write(row)
+}
+
+func (e *csvEncoder) encode(fe *api.FalcoEvent) error {
+	var fields map[string]any
+	if e.outputFields {
+		var err error
+		if fields, err = api.DecodeOutputFields(fe.Spec.OutputFields.Raw); err != nil {
+			return err
+		}
+	}
+	row := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		if fn, ok := columnFuncs[c]; ok {
+			row[i] = fn(fe)
+		} else {
+			row[i] = formatField(fields[c])
+		}
+		row[i] = neutralizeCell(row[i])
+	}
+	return e.w.Write(row)
+}
+
+// neutralizeCell prefixes the cells a spreadsheet would read as a formula with a quote, as the
+// events hold attacker controlled strings, eg, the command line of a process.
+func neutralizeCell(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func (e *csvEncoder) flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvEncoder) end() error { return e.flush() }
+
+func formatField(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	default:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+}
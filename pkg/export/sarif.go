@@ -0,0 +1,196 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string         `json:"id"`
+	Name             string         `json:"name"`
+	ShortDescription sarifMessage   `json:"shortDescription"`
+	Properties       map[string]any `json:"properties,omitempty"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName,omitempty"`
+	Kind               string `json:"kind"`
+}
+
+type sarifLocation struct {
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+// sarifEncoder writes a SARIF 2.1.0 log with a single run. The results are streamed first,
+// the rules they refer to are written with the tool once all events are seen.
+type sarifEncoder struct {
+	w     *bufio.Writer
+	rules []sarifRule
+	index map[string]int
+	n     int
+}
+
+func newSARIFEncoder(w io.Writer) *sarifEncoder {
+	return &sarifEncoder{w: bufio.NewWriter(w), index: map[string]int{}}
+}
+
+func (e *sarifEncoder) begin() error {
+	_, err := e.w.WriteString(`{"version":"` + sarifVersion + `","$schema":"` + sarifSchema + `","runs":[{"results":[`)
+	return err
+}
+
+func (e *sarifEncoder) encode(fe *api.FalcoEvent) error {
+	idx, ok := e.index[fe.Spec.Rule]
+	if !ok {
+		idx = len(e.rules)
+		e.index[fe.Spec.Rule] = idx
+		rule := sarifRule{
+			ID:               fe.Spec.Rule,
+			Name:             fe.Spec.Rule,
+			ShortDescription: sarifMessage{Text: fe.Spec.Rule},
+		}
+		if len(fe.Spec.Tags) > 0 {
+			rule.Properties = map[string]any{"tags": fe.Spec.Tags}
+		}
+		e.rules = append(e.rules, rule)
+	}
+
+	result := sarifResult{
+		RuleID:              fe.Spec.Rule,
+		RuleIndex:           idx,
+		Level:               sarifLevel(fe.Spec.Priority),
+		Message:             sarifMessage{Text: fe.Spec.Output},
+		PartialFingerprints: map[string]string{"falcoEvent": fe.Name},
+		Properties: map[string]any{
+			"priority":     fe.Spec.Priority,
+			"time":         fe.Spec.Time.UTC().Format(time.RFC3339Nano),
+			"source":       fe.Spec.Source,
+			"hostname":     fe.Spec.Hostname,
+			"count":        fe.Status.Count,
+			"outputFields": toFalcoPayload(fe).OutputFields,
+		},
+	}
+	if loc := sarifLocationOf(fe); loc != nil {
+		result.Locations = []sarifLocation{*loc}
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	if e.n > 0 {
+		if err := e.w.WriteByte(','); err != nil {
+			return err
+		}
+	}
+	e.n++
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *sarifEncoder) flush() error { return e.w.Flush() }
+
+func (e *sarifEncoder) end() error {
+	rules := e.rules
+	if rules == nil {
+		rules = []sarifRule{}
+	}
+	data, err := json.Marshal(sarifTool{Driver: sarifDriver{
+		Name:           "Falco",
+		InformationURI: "https://falco.org",
+		Rules:          rules,
+	}})
+	if err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString(`],"tool":`); err != nil {
+		return err
+	}
+	if _, err := e.w.Write(data); err != nil {
+		return err
+	}
+	if _, err := e.w.WriteString("}]}\n"); err != nil {
+		return err
+	}
+	return e.w.Flush()
+}
+
+// sarifLevel maps a Falco priority to a SARIF result level.
+func sarifLevel(p api.Priority) string {
+	switch p {
+	case api.PriorityEmergency, api.PriorityAlert, api.PriorityCritical, api.PriorityError:
+		return "error"
+	case api.PriorityWarning:
+		return "warning"
+	default:
+		return "note"
+	}
+}
+
+// sarifLocationOf returns the pod, or else the node, the event happened on.
+func sarifLocationOf(fe *api.FalcoEvent) *sarifLocation {
+	ns, pod := fe.Labels[api.FieldNamespaceName], fe.Labels[api.FieldPodName]
+	if ns != "" && pod != "" {
+		return &sarifLocation{LogicalLocations: []sarifLogicalLocation{{
+			Name:               pod,
+			FullyQualifiedName: ns + "/" + pod,
+			Kind:               "pod",
+		}}}
+	}
+	if node := fe.Labels["k8s.node.name"]; node != "" {
+		return &sarifLocation{LogicalLocations: []sarifLogicalLocation{{
+			Name: node,
+			Kind: "node",
+		}}}
+	}
+	return nil
+}
@@ -20,7 +20,9 @@ import (
 	"log"
 	"os"
 	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
 
 	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
 )
@@ -28,7 +30,14 @@ import (
 var (
 	config *types.Configuration
 
-	regPromLabels *regexp.Regexp
+	regPromLabels = regexp.MustCompile("^[a-zA-Z_:][a-zA-Z0-9_:]*$")
+)
+
+const (
+	// defaultMaxLabelValues is the default cap of distinct values of a Prometheus extra label.
+	defaultMaxLabelValues = 100
+	// defaultMutualTLSFilesPath is the default directory of the client certificate of the outputs with mutual TLS.
+	defaultMutualTLSFilesPath = "/etc/certs"
 )
 
 func init() {
@@ -40,17 +49,28 @@ func init() {
 		return
 	}
 
-	regPromLabels, _ = regexp.Compile("^[a-zA-Z_:][a-zA-Z0-9_:]*$")
-
 	config = getConfig()
 }
 
+// Config returns the configuration loaded from the environment. It is nil in unit tests.
+func Config() *types.Configuration {
+	return config
+}
+
 func getConfig() *types.Configuration {
 	c := &types.Configuration{
 		Customfields:    make(map[string]string),
 		BracketReplacer: "",
 		Debug:           false,
 	}
+	c.Prometheus.MaxLabelValues = defaultMaxLabelValues
+	c.MutualTLSFilesPath = defaultMutualTLSFilesPath
+	c.Slack.CheckCert = true
+	c.Webhook.CheckCert = true
+	c.Alertmanager.CheckCert = true
+	c.Loki.CheckCert = true
+	c.Elasticsearch.CheckCert = true
+	c.Elasticsearch.CreateIndexTemplate = true
 
 	// v.GetStringMapString("Customfields")
 
@@ -72,8 +92,177 @@ func getConfig() *types.Configuration {
 		}
 	}
 
+	// eg, PROMETHEUS_EXTRALABELS=container.image.repository,user.name
+	if value, present := os.LookupEnv("PROMETHEUS_EXTRALABELS"); present {
+		c.Prometheus.ExtraLabels = value
+	}
+	if value, present := os.LookupEnv("PROMETHEUS_MAXLABELVALUES"); present {
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			log.Printf("[ERROR] : Invalid PROMETHEUS_MAXLABELVALUES %q: %v", value, err)
+		} else {
+			c.Prometheus.MaxLabelValues = n
+		}
+	}
+
 	if c.Prometheus.ExtraLabels != "" {
 		c.Prometheus.ExtraLabelsList = strings.Split(strings.ReplaceAll(c.Prometheus.ExtraLabels, " ", ""), ",")
 	}
+
+	lookupString("MUTUALTLSFILESPATH", &c.MutualTLSFilesPath)
+
+	lookupString("SLACK_WEBHOOKURL", &c.Slack.WebhookURL)
+	lookupString("SLACK_CHANNEL", &c.Slack.Channel)
+	lookupString("SLACK_FOOTER", &c.Slack.Footer)
+	lookupString("SLACK_ICON", &c.Slack.Icon)
+	lookupString("SLACK_USERNAME", &c.Slack.Username)
+	lookupString("SLACK_OUTPUTFORMAT", &c.Slack.OutputFormat)
+	lookupString("SLACK_MINIMUMPRIORITY", &c.Slack.MinimumPriority)
+	lookupString("SLACK_MESSAGEFORMAT", &c.Slack.MessageFormat)
+	lookupBool("SLACK_CHECKCERT", &c.Slack.CheckCert)
+	lookupBool("SLACK_MUTUALTLS", &c.Slack.MutualTLS)
+	if c.Slack.MessageFormat != "" {
+		t, err := template.New("slack").Parse(c.Slack.MessageFormat)
+		if err != nil {
+			log.Printf("[ERROR] : Slack - Invalid SLACK_MESSAGEFORMAT: %v", err)
+		} else {
+			c.Slack.MessageFormatTemplate = t
+		}
+	}
+
+	// eg, WEBHOOK_CUSTOMHEADERS=Authorization:Bearer xxx,X-Cluster:prod
+	lookupString("WEBHOOK_ADDRESS", &c.Webhook.Address)
+	lookupString("WEBHOOK_METHOD", &c.Webhook.Method)
+	lookupMap("WEBHOOK_CUSTOMHEADERS", &c.Webhook.CustomHeaders)
+	lookupString("WEBHOOK_MINIMUMPRIORITY", &c.Webhook.MinimumPriority)
+	lookupString("WEBHOOK_FORMAT", &c.Webhook.Format)
+	lookupBool("WEBHOOK_CHECKCERT", &c.Webhook.CheckCert)
+	lookupBool("WEBHOOK_MUTUALTLS", &c.Webhook.MutualTLS)
+
+	// eg, ALERTMANAGER_CUSTOMSEVERITYMAP=critical:page,error:critical
+	lookupString("ALERTMANAGER_HOSTPORT", &c.Alertmanager.HostPort)
+	lookupString("ALERTMANAGER_ENDPOINT", &c.Alertmanager.Endpoint)
+	lookupInt("ALERTMANAGER_EXPIRESAFTER", &c.Alertmanager.ExpiresAfter)
+	lookupMap("ALERTMANAGER_EXTRALABELS", &c.Alertmanager.ExtraLabels)
+	lookupMap("ALERTMANAGER_EXTRAANNOTATIONS", &c.Alertmanager.ExtraAnnotations)
+	lookupList("ALERTMANAGER_OUTPUTFIELDLABELS", &c.Alertmanager.OutputFieldLabels)
+	lookupString("ALERTMANAGER_MINIMUMPRIORITY", &c.Alertmanager.MinimumPriority)
+	lookupBool("ALERTMANAGER_CHECKCERT", &c.Alertmanager.CheckCert)
+	lookupBool("ALERTMANAGER_MUTUALTLS", &c.Alertmanager.MutualTLS)
+	var severities map[string]string
+	lookupMap("ALERTMANAGER_CUSTOMSEVERITYMAP", &severities)
+	for priority, severity := range severities {
+		p := types.Priority(priority)
+		if p == types.Default {
+			log.Printf("[ERROR] : Alertmanager - Unknown priority %q in ALERTMANAGER_CUSTOMSEVERITYMAP", priority)
+			continue
+		}
+		if c.Alertmanager.CustomSeverityMap == nil {
+			c.Alertmanager.CustomSeverityMap = map[types.PriorityType]string{}
+		}
+		c.Alertmanager.CustomSeverityMap[p] = severity
+	}
+
+	// eg, LOKI_EXTRALABELS=k8s.pod.name,container.image.repository
+	lookupString("LOKI_HOSTPORT", &c.Loki.HostPort)
+	lookupString("LOKI_ENDPOINT", &c.Loki.Endpoint)
+	lookupString("LOKI_TENANT", &c.Loki.Tenant)
+	lookupString("LOKI_USER", &c.Loki.User)
+	lookupString("LOKI_APIKEY", &c.Loki.APIKey)
+	lookupString("LOKI_EXTRALABELS", &c.Loki.ExtraLabels)
+	lookupList("LOKI_EXTRALABELS", &c.Loki.ExtraLabelsList)
+	lookupMap("LOKI_CUSTOMHEADERS", &c.Loki.CustomHeaders)
+	lookupInt("LOKI_BATCHSIZE", &c.Loki.BatchSize)
+	lookupInt("LOKI_BATCHWAITSECONDS", &c.Loki.BatchWaitSeconds)
+	lookupString("LOKI_MINIMUMPRIORITY", &c.Loki.MinimumPriority)
+	lookupBool("LOKI_CHECKCERT", &c.Loki.CheckCert)
+	lookupBool("LOKI_MUTUALTLS", &c.Loki.MutualTLS)
+
+	lookupString("ELASTICSEARCH_HOSTPORT", &c.Elasticsearch.HostPort)
+	lookupString("ELASTICSEARCH_INDEX", &c.Elasticsearch.Index)
+	lookupString("ELASTICSEARCH_SUFFIX", &c.Elasticsearch.Suffix)
+	lookupString("ELASTICSEARCH_USERNAME", &c.Elasticsearch.Username)
+	lookupString("ELASTICSEARCH_PASSWORD", &c.Elasticsearch.Password)
+	lookupMap("ELASTICSEARCH_CUSTOMHEADERS", &c.Elasticsearch.CustomHeaders)
+	lookupBool("ELASTICSEARCH_CREATEINDEXTEMPLATE", &c.Elasticsearch.CreateIndexTemplate)
+	lookupInt("ELASTICSEARCH_BATCHSIZE", &c.Elasticsearch.BatchSize)
+	lookupInt("ELASTICSEARCH_BATCHWAITSECONDS", &c.Elasticsearch.BatchWaitSeconds)
+	lookupString("ELASTICSEARCH_MINIMUMPRIORITY", &c.Elasticsearch.MinimumPriority)
+	lookupBool("ELASTICSEARCH_CHECKCERT", &c.Elasticsearch.CheckCert)
+	lookupBool("ELASTICSEARCH_MUTUALTLS", &c.Elasticsearch.MutualTLS)
+
+	lookupBool("POLICYREPORT_ENABLED", &c.PolicyReport.Enabled)
+	lookupBool("POLICYREPORT_PRUNEBYPRIORITY", &c.PolicyReport.PruneByPriority)
+	lookupString("POLICYREPORT_MINIMUMPRIORITY", &c.PolicyReport.MinimumPriority)
+	lookupInt("POLICYREPORT_MAXEVENTS", &c.PolicyReport.MaxEvents)
 	return c
 }
+
+// lookupString sets v to the value of the environment variable, if it is present.
+func lookupString(key string, v *string) {
+	if value, present := os.LookupEnv(key); present {
+		*v = value
+	}
+}
+
+// lookupInt sets v to the value of the environment variable, if it is present and valid.
+func lookupInt(key string, v *int) {
+	value, present := os.LookupEnv(key)
+	if !present {
+		return
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		log.Printf("[ERROR] : Invalid %s %q: %v", key, value, err)
+		return
+	}
+	*v = n
+}
+
+// lookupList sets v to the comma separated values of the environment variable, if it is present.
+func lookupList(key string, v *[]string) {
+	value, present := os.LookupEnv(key)
+	if !present {
+		return
+	}
+	var out []string
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	*v = out
+}
+
+// lookupBool sets v to the value of the environment variable, if it is present and valid.
+func lookupBool(key string, v *bool) {
+	value, present := os.LookupEnv(key)
+	if !present {
+		return
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		log.Printf("[ERROR] : Invalid %s %q: %v", key, value, err)
+		return
+	}
+	*v = b
+}
+
+// lookupMap sets v to the key:value pairs of the environment variable, if it is present.
+// Pairs are separated by commas, a value may contain colons.
+func lookupMap(key string, v *map[string]string) {
+	value, present := os.LookupEnv(key)
+	if !present {
+		return
+	}
+	m := map[string]string{}
+	for _, pair := range strings.Split(value, ",") {
+		k, val, ok := strings.Cut(pair, ":")
+		if !ok || strings.TrimSpace(k) == "" {
+			log.Printf("[ERROR] : Invalid %s pair %q", key, pair)
+			continue
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(val)
+	}
+	*v = m
+}
@@ -20,29 +20,34 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"strings"
+	"sync"
 	"text/template"
 	"time"
 
-	"kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/outputs"
 	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
 
 	"github.com/google/uuid"
 	jsonx "gomodules.xyz/encoding/json"
 	core "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/klog/v2"
+	kutil "kmodules.xyz/client-go"
 	cu "kmodules.xyz/client-go/client"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Handler is Falco Sidekick main handler (default).
-func Handler(kc client.Client) http.Handler {
+// Handler is Falco Sidekick main handler (default). recorder and dispatcher may be nil.
+func Handler(kc client.Client, limits Limits, recorder *Recorder, dispatcher *outputs.Dispatcher) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if r.Body == nil {
 			http.Error(w, "Please send a valid request body", http.StatusBadRequest)
@@ -54,12 +59,38 @@ func Handler(kc client.Client) http.Handler {
 			return
 		}
 
-		falcopayload, err := newFalcoPayload(r.Body)
+		body := r.Body
+		if limits.MaxBodySize > 0 {
+			body = http.MaxBytesReader(w, r.Body, limits.MaxBodySize)
+		}
+		falcopayload, err := newFalcoPayload(body)
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			payloadsRejected.WithLabelValues(RejectReasonBodyTooLarge).Inc()
+			http.Error(w, fmt.Sprintf("Request body exceeds %d bytes", maxBytesErr.Limit), http.StatusRequestEntityTooLarge)
+			return
+		}
 		if err != nil || !falcopayload.Check() {
+			payloadsRejected.WithLabelValues(RejectReasonInvalid).Inc()
 			http.Error(w, "Please send a valid request body", http.StatusBadRequest)
 			return
 		}
-		mustForwardEvent(kc, falcopayload)
+
+		lv := events.labelValues(&falcopayload)
+		events.received.WithLabelValues(lv...).Inc()
+
+		truncated, ok := limits.apply(&falcopayload)
+		if !ok {
+			payloadsRejected.WithLabelValues(RejectReasonEventTooLarge).Inc()
+			events.dropped.WithLabelValues(lv...).Inc()
+			http.Error(w, fmt.Sprintf("Event exceeds %d bytes", limits.MaxEventSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		if len(truncated) > 0 {
+			payloadsTruncated.Inc()
+			fieldsTruncated.Add(float64(len(truncated)))
+		}
+		mustForwardEvent(kc, recorder, dispatcher, falcopayload, truncated, lv)
 	})
 }
 
@@ -89,18 +120,6 @@ func newFalcoPayload(payload io.Reader) (types.FalcoPayload, error) {
 
 	falcopayload.UUID = uuid.New().String()
 
-	var kn, kp string
-	for i, j := range falcopayload.OutputFields {
-		if j != nil {
-			if i == "k8s.ns.name" {
-				kn = j.(string)
-			}
-			if i == "k8s.pod.name" {
-				kp = j.(string)
-			}
-		}
-	}
-
 	if len(config.Templatedfields) > 0 {
 		if falcopayload.OutputFields == nil {
 			falcopayload.OutputFields = make(map[string]any)
@@ -119,30 +138,6 @@ func newFalcoPayload(payload io.Reader) (types.FalcoPayload, error) {
 		}
 	}
 
-	promLabels := map[string]string{"rule": falcopayload.Rule, "priority": falcopayload.Priority.String(), "k8s_ns_name": kn, "k8s_pod_name": kp}
-	if falcopayload.Hostname != "" {
-		promLabels["hostname"] = falcopayload.Hostname
-	}
-
-	for key, value := range config.Customfields {
-		if regPromLabels.MatchString(key) {
-			promLabels[key] = value
-		}
-	}
-	for _, i := range config.Prometheus.ExtraLabelsList {
-		promLabels[strings.ReplaceAll(i, ".", "_")] = ""
-		for key, value := range falcopayload.OutputFields {
-			if key == i && regPromLabels.MatchString(strings.ReplaceAll(key, ".", "_")) {
-				switch value.(type) {
-				case string:
-					promLabels[strings.ReplaceAll(key, ".", "_")] = fmt.Sprintf("%v", value)
-				default:
-					continue
-				}
-			}
-		}
-	}
-
 	if config.BracketReplacer != "" {
 		for i, j := range falcopayload.OutputFields {
 			if strings.Contains(i, "[") {
@@ -160,13 +155,18 @@ func newFalcoPayload(payload io.Reader) (types.FalcoPayload, error) {
 	return falcopayload, nil
 }
 
-func forwardEvent(kc client.Client, payload types.FalcoPayload, evHash uint64) error {
+// eventName returns the name of the FalcoEvent storing the events with the hash.
+func eventName(hash uint64) string {
+	return fmt.Sprintf("fe-%d", hash)
+}
+
+func forwardEvent(kc client.Client, recorder *Recorder, dispatcher *outputs.Dispatcher, payload types.FalcoPayload, truncated []string, evHash uint64, occurrences int64) error {
 	var nodeName string
 	if payload.Hostname != "" {
 		nodeName = payload.Hostname
 	} else {
-		podName := payload.OutputFields["k8s.pod.name"].(string)
-		nsName := payload.OutputFields["k8s.ns.name"].(string)
+		podName, _ := payload.OutputFields["k8s.pod.name"].(string)
+		nsName, _ := payload.OutputFields["k8s.ns.name"].(string)
 		if podName != "" && nsName != "" {
 			var pod core.Pod
 			key := client.ObjectKey{
@@ -179,22 +179,22 @@ func forwardEvent(kc client.Client, payload types.FalcoPayload, evHash uint64) e
 		}
 	}
 
-	obj := &v1alpha1.FalcoEvent{
+	obj := &v1beta1.FalcoEvent{
 		TypeMeta: metav1.TypeMeta{
-			APIVersion: v1alpha1.SchemeGroupVersion.String(),
-			Kind:       v1alpha1.ResourceKindFalcoEvent,
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       v1beta1.ResourceKindFalcoEvent,
 		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:        fmt.Sprintf("fe-%d", evHash),
+			Name:        eventName(evHash),
 			Labels:      map[string]string{},
 			Annotations: nil,
 		},
-		Spec: v1alpha1.FalcoEventSpec{
+		Spec: v1beta1.FalcoEventSpec{
 			UUID:     payload.UUID,
 			Output:   payload.Output,
-			Priority: payload.Priority.String(),
+			Priority: v1beta1.Priority(payload.Priority.String()),
 			Rule:     payload.Rule,
-			Time:     metav1.NewTime(payload.Time),
+			Time:     metav1.NewMicroTime(payload.Time),
 			// OutputFields: apiextensionsv1.JSON{},
 			Source:   payload.Source,
 			Tags:     payload.Tags,
@@ -212,7 +212,7 @@ func forwardEvent(kc client.Client, payload types.FalcoPayload, evHash uint64) e
 		switch k {
 		case "k8s.ns.name", "k8s.pod.name":
 			val, ok := v.(string)
-			if ok {
+			if ok && val != "" {
 				obj.Labels[k] = val
 			}
 		}
@@ -221,31 +221,146 @@ func forwardEvent(kc client.Client, payload types.FalcoPayload, evHash uint64) e
 		obj.Labels["k8s.node.name"] = nodeName
 		obj.Spec.Nodename = nodeName
 	}
+	if len(truncated) > 0 {
+		obj.Annotations = map[string]string{
+			v1beta1.AnnotationTruncatedFields: strings.Join(truncated, ","),
+		}
+	}
 
-	_, err = cu.CreateOrPatch(context.TODO(), kc, obj, func(in client.Object, createOp bool) client.Object {
-		o := in.(*v1alpha1.FalcoEvent)
+	start := time.Now()
+	defer func() {
+		writeLatency.Observe(time.Since(start).Seconds())
+	}()
+	vt, err := cu.CreateOrPatch(context.TODO(), kc, obj, func(in client.Object, createOp bool) client.Object {
+		o := in.(*v1beta1.FalcoEvent)
 		o.Labels = obj.Labels
+		if len(truncated) > 0 {
+			if o.Annotations == nil {
+				o.Annotations = map[string]string{}
+			}
+			o.Annotations[v1beta1.AnnotationTruncatedFields] = obj.Annotations[v1beta1.AnnotationTruncatedFields]
+		} else {
+			delete(o.Annotations, v1beta1.AnnotationTruncatedFields)
+		}
 		o.Spec = obj.Spec
 
 		return o
 	})
+	if err != nil {
+		return err
+	}
+
+	if err := addOccurrences(context.TODO(), kc, obj, occurrences); err != nil {
+		return err
+	}
+
+	if vt == kutil.VerbCreated {
+		recorder.Record(context.TODO(), obj)
+		dispatcher.Enqueue(&outputs.Event{Name: obj.Name, FalcoEvent: obj, Payload: payload})
+	} else {
+		dispatcher.Enqueue(&outputs.Event{Name: obj.Name, Payload: payload})
+	}
+	return nil
+}
+
+// maxStatusAttempts is the number of attempts to update the status of a FalcoEvent, which
+// fail if another replica updated it concurrently.
+const maxStatusAttempts = 5
+
+// addOccurrences adds the occurrences to the count of the FalcoEvent and sets the time it was
+// last seen. The status is patched with the resourceVersion it was read at, so concurrent
+// updates by other replicas are not lost.
+func addOccurrences(ctx context.Context, kc client.Client, obj *v1beta1.FalcoEvent, occurrences int64) error {
+	seen := obj.Spec.Time
+	var err error
+	for attempt := 0; attempt < maxStatusAttempts; attempt++ {
+		var cur v1beta1.FalcoEvent
+		if err = kc.Get(ctx, client.ObjectKeyFromObject(obj), &cur); err != nil {
+			return err
+		}
+		orig := cur.DeepCopy()
+		cur.Status.Count += occurrences
+		if cur.Status.FirstSeen == nil {
+			cur.Status.FirstSeen = &seen
+		}
+		cur.Status.LastSeen = &seen
+		if cur.Status.TriageState == "" {
+			cur.Status.TriageState = v1beta1.TriageStateOpen
+		}
+		err = kc.Status().Patch(ctx, &cur, client.MergeFromWithOptions(orig, client.MergeFromWithOptimisticLock{}))
+		if err == nil {
+			obj.Status = cur.Status
+			return nil
+		}
+		if !apierrors.IsConflict(err) {
+			return err
+		}
+	}
 	return err
 }
 
-var eventHashes = make(map[uint64]time.Time)
+// eventRecord tracks when an event was last written and how often
+// it occurred since then.
+type eventRecord struct {
+	lastWritten time.Time
+	pending     int64
+	// writing is true while the event is written, the payloads received meanwhile are pending.
+	writing bool
+	// uuid is the UUID of the last payload written to the FalcoEvent.
+	uuid string
+}
+
+var (
+	eventMu     sync.Mutex
+	eventHashes = make(map[uint64]*eventRecord)
+)
 
 const eventRefreshTTL = 10 * time.Minute
 
-func mustForwardEvent(kc client.Client, payload types.FalcoPayload) {
+// mustForwardEvent writes the event, unless an identical event was written recently. lv are the
+// values of the event counter labels.
+func mustForwardEvent(kc client.Client, recorder *Recorder, dispatcher *outputs.Dispatcher, payload types.FalcoPayload, truncated []string, lv []string) {
 	hashKey := payload.HashKey()
-	lastTime, found := eventHashes[hashKey]
 
-	if !found || time.Since(lastTime) > eventRefreshTTL {
-		err := forwardEvent(kc, payload, hashKey)
-		if err = client.IgnoreAlreadyExists(err); err != nil {
-			klog.ErrorS(err, "failed to write falco event")
-		} else {
-			eventHashes[hashKey] = payload.Time
-		}
+	eventMu.Lock()
+	rec, found := eventHashes[hashKey]
+	if !found {
+		rec = &eventRecord{}
+		eventHashes[hashKey] = rec
+	}
+	if rec.writing || found && time.Since(rec.lastWritten) <= eventRefreshTTL {
+		rec.pending++
+		uuid := rec.uuid
+		eventMu.Unlock()
+		incWithExemplar(events.deduplicated.WithLabelValues(lv...), eventName(hashKey), uuid)
+		dispatcher.Enqueue(&outputs.Event{Name: eventName(hashKey), Payload: payload})
+		return
+	}
+	// the payload and the ones deduplicated since the last write
+	occurrences := rec.pending + 1
+	rec.pending = 0
+	rec.writing = true
+	eventMu.Unlock()
+
+	err := forwardEvent(kc, recorder, dispatcher, payload, truncated, hashKey, occurrences)
+	err = client.IgnoreAlreadyExists(err)
+
+	eventMu.Lock()
+	rec.writing = false
+	if err != nil {
+		// the deduplicated payloads are counted by the next write
+		rec.pending += occurrences - 1
+	} else {
+		rec.lastWritten = payload.Time
+		rec.uuid = payload.UUID
+	}
+	eventMu.Unlock()
+
+	if err != nil {
+		events.failed.WithLabelValues(lv...).Inc()
+		klog.ErrorS(err, "failed to write falco event")
+		return
 	}
+	incWithExemplar(events.stored.WithLabelValues(lv...), eventName(hashKey), payload.UUID)
+	ingestLatency.Observe(time.Since(payload.Time).Seconds())
 }
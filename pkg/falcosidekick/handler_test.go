@@ -0,0 +1,189 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var falcoEventsResource = schema.GroupResource{Group: v1beta1.SchemeGroupVersion.Group, Resource: v1beta1.ResourceFalcoEvents}
+
+// fakeClient stores FalcoEvents. Creates wait for release, if set, and status patches fail
+// with a conflict while conflicts is positive, after another replica added 10 to the count.
+type fakeClient struct {
+	client.Client
+
+	mu        sync.Mutex
+	scheme    *runtime.Scheme
+	events    map[string]*v1beta1.FalcoEvent
+	rv        int
+	conflicts int
+
+	creating chan struct{}
+	release  chan struct{}
+}
+
+func newFakeClient(t *testing.T) *fakeClient {
+	scheme := runtime.NewScheme()
+	if err := v1beta1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return &fakeClient{scheme: scheme, events: map[string]*v1beta1.FalcoEvent{}}
+}
+
+func (f *fakeClient) Scheme() *runtime.Scheme { return f.scheme }
+
+func (f *fakeClient) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fe, ok := f.events[key.Name]
+	if !ok {
+		return apierrors.NewNotFound(falcoEventsResource, key.Name)
+	}
+	fe.DeepCopyInto(obj.(*v1beta1.FalcoEvent))
+	return nil
+}
+
+func (f *fakeClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	if f.release != nil {
+		f.creating <- struct{}{}
+		<-f.release
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.events[obj.GetName()]; ok {
+		return apierrors.NewAlreadyExists(falcoEventsResource, obj.GetName())
+	}
+	f.store(obj.(*v1beta1.FalcoEvent))
+	return nil
+}
+
+func (f *fakeClient) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.PatchOption) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.store(obj.(*v1beta1.FalcoEvent))
+	return nil
+}
+
+func (f *fakeClient) store(fe *v1beta1.FalcoEvent) {
+	f.rv++
+	fe.ResourceVersion = strconv.Itoa(f.rv)
+	f.events[fe.Name] = fe.DeepCopy()
+}
+
+func (f *fakeClient) Status() client.SubResourceWriter { return fakeStatusWriter{f: f} }
+
+type fakeStatusWriter struct {
+	client.SubResourceWriter
+	f *fakeClient
+}
+
+func (w fakeStatusWriter) Patch(_ context.Context, obj client.Object, _ client.Patch, _ ...client.SubResourcePatchOption) error {
+	f := w.f
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	cur := f.events[obj.GetName()]
+	if f.conflicts > 0 {
+		f.conflicts--
+		cur.Status.Count += 10
+		f.store(cur)
+	}
+	if obj.GetResourceVersion() != cur.ResourceVersion {
+		return apierrors.NewConflict(falcoEventsResource, obj.GetName(), nil)
+	}
+	cur.Status = obj.(*v1beta1.FalcoEvent).Status
+	f.store(cur)
+	return nil
+}
+
+func TestForwardEvent(t *testing.T) {
+	kc := newFakeClient(t)
+	kc.creating = make(chan struct{})
+	kc.release = make(chan struct{})
+
+	payload := types.FalcoPayload{
+		UUID:         "uuid-1",
+		Rule:         "TestForwardEvent",
+		Priority:     types.Warning,
+		Output:       "A shell was spawned",
+		Source:       "syscall",
+		Hostname:     "node-1",
+		Time:         time.Now(),
+		OutputFields: map[string]any{"k8s.ns.name": "shop", "k8s.pod.name": "cart-0"},
+	}
+	lv := events.labelValues(&payload)
+	hash := payload.HashKey()
+	name := eventName(hash)
+
+	// a duplicate received while the event is created is pending
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		mustForwardEvent(kc, nil, nil, payload, nil, lv)
+	}()
+	<-kc.creating
+	mustForwardEvent(kc, nil, nil, payload, nil, lv)
+	close(kc.release)
+	<-done
+	kc.release = nil
+
+	fe := kc.events[name]
+	if fe == nil {
+		t.Fatalf("FalcoEvent %s is not created", name)
+	}
+	if fe.Status.Count != 1 || fe.Status.TriageState != v1beta1.TriageStateOpen || fe.Status.FirstSeen == nil {
+		t.Errorf("got status %+v, want count 1 and Open", fe.Status)
+	}
+	eventMu.Lock()
+	rec := eventHashes[hash]
+	if rec.pending != 1 || rec.writing {
+		t.Errorf("got %d pending payloads, writing %v, want 1 pending", rec.pending, rec.writing)
+	}
+	// the next payload is written once the record is stale
+	rec.lastWritten = time.Now().Add(-2 * eventRefreshTTL)
+	eventMu.Unlock()
+
+	// the status is patched again after a concurrent update by another replica
+	kc.conflicts = 1
+	mustForwardEvent(kc, nil, nil, payload, nil, lv)
+	if got := kc.events[name].Status.Count; got != 13 {
+		t.Errorf("got count %d, want 13: 1 stored, 10 by another replica, 2 by this write", got)
+	}
+	eventMu.Lock()
+	if rec.pending != 0 {
+		t.Errorf("got %d pending payloads after the write, want 0", rec.pending)
+	}
+	eventMu.Unlock()
+
+	// payloads within the refresh TTL are deduplicated
+	mustForwardEvent(kc, nil, nil, payload, nil, lv)
+	if got := kc.events[name].Status.Count; got != 13 {
+		t.Errorf("got count %d after a deduplicated payload, want 13", got)
+	}
+}
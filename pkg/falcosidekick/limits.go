@@ -0,0 +1,152 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"encoding/json"
+	"sort"
+	"unicode/utf8"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+const (
+	DefaultMaxBodySize  = 1 << 20
+	DefaultMaxFieldSize = 4 << 10
+	DefaultMaxEventSize = 128 << 10
+
+	// minTruncatedFieldSize is the size a field is never truncated below to fit the event budget.
+	minTruncatedFieldSize = 64
+
+	// outputKey names the event output in the list of truncated fields.
+	outputKey = "output"
+)
+
+// Limits bounds the size of the Falco payloads accepted by the handler. Zero disables a limit.
+type Limits struct {
+	// MaxBodySize is the maximum size of a request body in bytes.
+	MaxBodySize int64
+	// MaxFieldSize is the maximum size of the output and of a single output field value in bytes.
+	MaxFieldSize int
+	// MaxEventSize is the maximum size of the output and all output fields of an event in bytes.
+	MaxEventSize int
+}
+
+// DefaultLimits returns limits that keep FalcoEvents well within the size accepted by the api server.
+func DefaultLimits() Limits {
+	return Limits{
+		MaxBodySize:  DefaultMaxBodySize,
+		MaxFieldSize: DefaultMaxFieldSize,
+		MaxEventSize: DefaultMaxEventSize,
+	}
+}
+
+// apply truncates the string values of a payload that exceed the limits. It returns the sorted
+// names of the truncated fields, or false if the event does not fit the event budget even after
+// truncating all of its strings.
+func (l Limits) apply(p *types.FalcoPayload) ([]string, bool) {
+	truncated := map[string]bool{}
+
+	if l.MaxFieldSize > 0 {
+		if s, ok := truncateString(p.Output, l.MaxFieldSize); ok {
+			p.Output = s
+			truncated[outputKey] = true
+		}
+		for k, v := range p.OutputFields {
+			if str, ok := v.(string); ok {
+				if s, ok := truncateString(str, l.MaxFieldSize); ok {
+					p.OutputFields[k] = s
+					truncated[k] = true
+				}
+			}
+		}
+	}
+
+	if l.MaxEventSize > 0 {
+		type field struct {
+			key  string
+			size int
+		}
+		total := len(p.Output)
+		fields := []field{{key: outputKey, size: len(p.Output)}}
+		for k, v := range p.OutputFields {
+			total += len(k) + valueSize(v)
+			if str, ok := v.(string); ok {
+				fields = append(fields, field{key: k, size: len(str)})
+			}
+		}
+		// cut the largest strings first, ties are broken by name to keep the result stable
+		sort.Slice(fields, func(i, j int) bool {
+			if fields[i].size != fields[j].size {
+				return fields[i].size > fields[j].size
+			}
+			return fields[i].key < fields[j].key
+		})
+		for _, f := range fields {
+			if total <= l.MaxEventSize {
+				break
+			}
+			keep := max(f.size-(total-l.MaxEventSize), minTruncatedFieldSize)
+			if keep >= f.size {
+				continue
+			}
+			var s string
+			if f.key == outputKey {
+				s, _ = truncateString(p.Output, keep)
+				p.Output = s
+			} else {
+				s, _ = truncateString(p.OutputFields[f.key].(string), keep)
+				p.OutputFields[f.key] = s
+			}
+			total -= f.size - len(s)
+			truncated[f.key] = true
+		}
+		if total > l.MaxEventSize {
+			return nil, false
+		}
+	}
+
+	names := make([]string, 0, len(truncated))
+	for k := range truncated {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+	return names, true
+}
+
+// truncateString cuts s to at most n bytes without splitting a multi-byte character.
+func truncateString(s string, n int) (string, bool) {
+	if len(s) <= n {
+		return s, false
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], true
+}
+
+func valueSize(v any) int {
+	switch v := v.(type) {
+	case string:
+		return len(v)
+	case nil:
+		return 0
+	default:
+		data, _ := json.Marshal(v)
+		return len(data)
+	}
+}
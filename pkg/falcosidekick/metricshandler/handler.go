@@ -17,66 +17,38 @@ limitations under the License.
 package metricshandler
 
 import (
-	"io"
 	"net/http"
+	"strings"
 
-	"kubeops.dev/ui-server/pkg/metricsstore"
-
-	"k8s.io/kube-state-metrics/v2/pkg/metric"
-	generator "k8s.io/kube-state-metrics/v2/pkg/metric_generator"
-	"sigs.k8s.io/controller-runtime/pkg/client"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	dto "github.com/prometheus/client_model/go"
 )
 
 const (
 	falcoMetricPrefix = "falco_appscode_com_"
 )
 
-func Handler(kc client.Client) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		if r.Body == nil {
-			http.Error(w, "Please send a valid request body", http.StatusBadRequest)
-			return
-		}
-
-		if r.Method == http.MethodGet {
-			err := CollectMetrics(kc, w)
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusBadRequest)
-			}
-			return
-		}
-	})
+// Handler serves the falco metrics of the gatherer, ie, the ingest counters and the metrics of
+// the cleaner and the quotas, without the metrics of the Go runtime and controller-runtime.
+// The counters are maintained in the ingest path, so a scrape does not list the FalcoEvents.
+// Scrapers accepting OpenMetrics get the exemplars linking the samples to FalcoEvents, the
+// others get the text format.
+func Handler(g prometheus.Gatherer) http.Handler {
+	return promhttp.HandlerFor(falcoGatherer{g}, promhttp.HandlerOpts{EnableOpenMetrics: true})
 }
 
-func CollectMetrics(kc client.Client, w io.Writer) error {
-	generators := getFamilyGenerators()
-	if len(generators) == 0 {
-		_, err := w.Write([]byte("OK"))
-		return err
-	}
-
-	// Generate the headers for the resources metrics
-	headers := generator.ExtractMetricFamilyHeaders(generators)
-	store := metricsstore.NewMetricsStore(headers)
-
-	if all, err := collectFalcoMetrics(kc, generators[0]); err != nil {
-		return err
-	} else {
-		store.Add(all)
-	}
-
-	return store.WriteAll(w)
+type falcoGatherer struct {
+	prometheus.Gatherer
 }
 
-func getFamilyGenerators() []generator.FamilyGenerator {
-	fn := func(obj any) *metric.Family { return new(metric.Family) }
-	generators := make([]generator.FamilyGenerator, 0, 1)
-	generators = append(generators, generator.FamilyGenerator{
-		Name:              falcoMetricPrefix + "events",
-		Help:              "All statistics",
-		Type:              metric.Gauge,
-		DeprecatedVersion: "",
-		GenerateFunc:      fn,
-	})
-	return generators
+func (g falcoGatherer) Gather() ([]*dto.MetricFamily, error) {
+	families, err := g.Gatherer.Gather()
+	out := families[:0]
+	for _, mf := range families {
+		if strings.HasPrefix(mf.GetName(), falcoMetricPrefix) {
+			out = append(out, mf)
+		}
+	}
+	return out, err
 }
@@ -0,0 +1,63 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metricshandler
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestHandler(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	received := prometheus.NewCounterVec(prometheus.CounterOpts{Name: falcoMetricPrefix + "events_received_total"}, []string{"rule"})
+	reg.MustRegister(received, prometheus.NewCounter(prometheus.CounterOpts{Name: "workqueue_adds_total"}))
+	received.WithLabelValues("Terminal shell in container").Add(3)
+
+	w := httptest.NewRecorder()
+	Handler(reg).ServeHTTP(w, httptest.NewRequest("GET", "/falcometrics", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, `falco_appscode_com_events_received_total{rule="Terminal shell in container"} 3`) {
+		t.Errorf("missing ingest counter in\n%s", body)
+	}
+	if strings.Contains(body, "workqueue_adds_total") {
+		t.Errorf("unexpected non falco metric in\n%s", body)
+	}
+}
+
+func TestHandlerExemplars(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	stored := prometheus.NewCounter(prometheus.CounterOpts{Name: falcoMetricPrefix + "events_stored_total"})
+	reg.MustRegister(stored)
+	stored.(prometheus.ExemplarAdder).AddWithExemplar(1, prometheus.Labels{"name": "fe-42", "uuid": "5f1c7c4e-6b2a-4f0e-9d51-7f3a2c1b0e9d"})
+
+	req := httptest.NewRequest("GET", "/falcometrics", nil)
+	req.Header.Set("Accept", "application/openmetrics-text; version=1.0.0")
+	w := httptest.NewRecorder()
+	Handler(reg).ServeHTTP(w, req)
+	if body := w.Body.String(); !strings.Contains(body, `# {name="fe-42",uuid="5f1c7c4e-6b2a-4f0e-9d51-7f3a2c1b0e9d"} 1`) {
+		t.Errorf("missing exemplar in\n%s", body)
+	}
+
+	w = httptest.NewRecorder()
+	Handler(reg).ServeHTTP(w, httptest.NewRequest("GET", "/falcometrics", nil))
+	if body := w.Body.String(); strings.Contains(body, "fe-42") {
+		t.Errorf("unexpected exemplar in text format\n%s", body)
+	}
+}
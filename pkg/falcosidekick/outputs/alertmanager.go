@@ -0,0 +1,236 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+	"kubeops.dev/falco-ui-server/pkg/index"
+)
+
+const (
+	// DefaultAlertmanagerEndpoint is the path of the Alertmanager API alerts are posted to.
+	DefaultAlertmanagerEndpoint = "/api/v2/alerts"
+	// defaultAlertExpiry is the time an alert is active for without an ExpiresAfter.
+	defaultAlertExpiry = 10 * time.Minute
+)
+
+// defaultSeverities are the severity labels of the priorities, the same as falcosidekick.
+var defaultSeverities = map[types.PriorityType]string{
+	types.Emergency:     "critical",
+	types.Alert:         "critical",
+	types.Critical:      "critical",
+	types.Error:         "warning",
+	types.Warning:       "warning",
+	types.Notice:        "information",
+	types.Informational: "information",
+	types.Debug:         "information",
+}
+
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// labelName returns the output field as a valid label name, eg, k8s.ns.name as k8s_ns_name.
+func labelName(field string) string {
+	name := invalidLabelChars.ReplaceAllString(field, "_")
+	if name == "" || name[0] >= '0' && name[0] <= '9' {
+		name = "_" + name
+	}
+	return name
+}
+
+// Alertmanager posts the events as alerts to the Alertmanager API. An alert is identified by
+// its FalcoEvent and expires unless it is re-sent, so it is re-sent while the event recurs.
+type Alertmanager struct {
+	cfg         types.AlertmanagerOutputConfig
+	opts        Options
+	minPriority types.PriorityType
+	severities  map[types.PriorityType]string
+	expiry      time.Duration
+	client      *Client
+
+	mu sync.Mutex
+	// active are the alerts sent and not expired yet, by FalcoEvent
+	active    map[string]*activeAlert
+	lastPrune time.Time
+}
+
+type activeAlert struct {
+	startsAt time.Time
+	sentAt   time.Time
+}
+
+var _ RecurrenceOutput = &Alertmanager{}
+
+func NewAlertmanager(cfg types.AlertmanagerOutputConfig, mutualTLSPath string, opts Options) (*Alertmanager, error) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultAlertmanagerEndpoint
+	}
+	c, err := NewClient(strings.TrimSuffix(cfg.HostPort, "/")+cfg.Endpoint, cfg.CheckCert, cfg.MutualTLS, mutualTLSPath)
+	if err != nil {
+		return nil, fmt.Errorf("alertmanager: %v", err)
+	}
+	severities := make(map[types.PriorityType]string, len(defaultSeverities))
+	for p, s := range defaultSeverities {
+		severities[p] = s
+	}
+	for p, s := range cfg.CustomSeverityMap {
+		severities[p] = s
+	}
+	expiry := time.Duration(cfg.ExpiresAfter) * time.Second
+	if expiry <= 0 {
+		expiry = defaultAlertExpiry
+	}
+	return &Alertmanager{
+		cfg:         cfg,
+		opts:        opts,
+		minPriority: types.Priority(cfg.MinimumPriority),
+		severities:  severities,
+		expiry:      expiry,
+		client:      c,
+		active:      map[string]*activeAlert{},
+	}, nil
+}
+
+func (a *Alertmanager) Name() string {
+	return "alertmanager"
+}
+
+func (a *Alertmanager) MinimumPriority() types.PriorityType {
+	return a.minPriority
+}
+
+// SendRecurrences returns true, the alerts are kept active while their event recurs.
+func (a *Alertmanager) SendRecurrences() bool {
+	return true
+}
+
+// alert is an alert of the Alertmanager v2 API.
+type alert struct {
+	Labels       map[string]string `json:"labels"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+	StartsAt     time.Time         `json:"startsAt"`
+	EndsAt       time.Time         `json:"endsAt"`
+	GeneratorURL string            `json:"generatorURL,omitempty"`
+}
+
+func (a *Alertmanager) Send(ctx context.Context, ev *Event) error {
+	now := time.Now()
+	startsAt, ok := a.track(ev, now)
+	if !ok {
+		return nil
+	}
+	doc := []alert{a.alert(ev, startsAt, now)}
+	err := withRetries(ctx, a.Name(), stats.Alertmanager, func() error {
+		return a.client.Post(ctx, doc)
+	})
+	if err != nil {
+		a.forget(ev.Name)
+	}
+	return err
+}
+
+// track records the alert of the event as sent and returns its start. It returns false for the
+// recurrences of an alert sent less than half its expiry ago, which is still active.
+func (a *Alertmanager) track(ev *Event, now time.Time) (time.Time, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if now.Sub(a.lastPrune) > a.expiry {
+		for name, s := range a.active {
+			if now.Sub(s.sentAt) > a.expiry {
+				delete(a.active, name)
+			}
+		}
+		a.lastPrune = now
+	}
+
+	s, ok := a.active[ev.Name]
+	if ok && now.Sub(s.sentAt) > a.expiry {
+		ok = false
+	}
+	if !ok {
+		s = &activeAlert{startsAt: ev.Payload.Time}
+		if s.startsAt.IsZero() {
+			s.startsAt = now
+		}
+		a.active[ev.Name] = s
+	} else if ev.Recurrence() && now.Sub(s.sentAt) < a.expiry/2 {
+		return time.Time{}, false
+	}
+	s.sentAt = now
+	return s.startsAt, true
+}
+
+func (a *Alertmanager) forget(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	delete(a.active, name)
+}
+
+func (a *Alertmanager) alert(ev *Event, startsAt, now time.Time) alert {
+	p := ev.Payload
+	labels := map[string]string{}
+	for _, f := range a.cfg.OutputFieldLabels {
+		if v, ok := p.OutputFields[f]; ok && v != nil {
+			if s := fmt.Sprint(v); s != "" {
+				labels[labelName(f)] = s
+			}
+		}
+	}
+	for k, v := range a.cfg.ExtraLabels {
+		labels[k] = v
+	}
+	labels["alertname"] = p.Rule
+	labels["rule"] = p.Rule
+	labels["priority"] = p.Priority.String()
+	labels["severity"] = a.severities[p.Priority]
+	labels["source"] = p.Source
+	labels["falcoevent"] = ev.Name
+	if p.Hostname != "" {
+		labels["hostname"] = p.Hostname
+	}
+	ns, _ := p.OutputFields["k8s.ns.name"].(string)
+	pod, _ := p.OutputFields["k8s.pod.name"].(string)
+	if ns != "" {
+		labels["namespace"] = ns
+	}
+	if pod != "" {
+		labels["pod"] = pod
+		labels["workload"] = index.WorkloadName(pod)
+	}
+
+	annotations := map[string]string{}
+	for k, v := range a.cfg.ExtraAnnotations {
+		annotations[k] = v
+	}
+	annotations["summary"] = p.Rule
+	annotations["description"] = p.Output
+	annotations["kubectl"] = KubectlCommand(ev.Name)
+
+	return alert{
+		Labels:       labels,
+		Annotations:  annotations,
+		StartsAt:     startsAt,
+		EndsAt:       now.Add(a.expiry),
+		GeneratorURL: a.opts.Link(ev.Name),
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+func TestAlertmanager(t *testing.T) {
+	alerts := make(chan []alert, 10)
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		var doc []alert
+		if err := json.NewDecoder(r.Body).Decode(&doc); err != nil {
+			t.Error(err)
+		}
+		alerts <- doc
+	}))
+	defer srv.Close()
+
+	a, err := NewAlertmanager(types.AlertmanagerOutputConfig{
+		HostPort:          srv.URL,
+		ExpiresAfter:      600,
+		CustomSeverityMap: map[types.PriorityType]string{types.Warning: "minor"},
+		OutputFieldLabels: []string{"proc.name"},
+		ExtraLabels:       map[string]string{"team": "shop"},
+	}, "", Options{UIURL: "https://falco.example.com"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ev := newEvent("fe-1", v1beta1.PriorityWarning)
+	ev.Payload.Source = "syscall"
+	ev.Payload.Time = time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	ev.Payload.OutputFields = map[string]interface{}{
+		"k8s.ns.name":  "shop",
+		"k8s.pod.name": "cart-7d4b9c8f6d-x2x9z",
+		"proc.name":    "bash",
+	}
+	before := time.Now()
+	if err := a.Send(context.Background(), ev); err != nil {
+		t.Fatal(err)
+	}
+	doc := <-alerts
+	if path != DefaultAlertmanagerEndpoint {
+		t.Errorf("got path %q, want %q", path, DefaultAlertmanagerEndpoint)
+	}
+	if len(doc) != 1 {
+		t.Fatalf("got %d alerts, want 1", len(doc))
+	}
+	got := doc[0]
+	for k, v := range map[string]string{
+		"alertname":  "Terminal shell in container",
+		"priority":   "Warning",
+		"severity":   "minor",
+		"falcoevent": "fe-1",
+		"namespace":  "shop",
+		"workload":   "cart",
+		"proc_name":  "bash",
+		"team":       "shop",
+	} {
+		if got.Labels[k] != v {
+			t.Errorf("got label %s=%q, want %q", k, got.Labels[k], v)
+		}
+	}
+	if !got.StartsAt.Equal(ev.Payload.Time) {
+		t.Errorf("got startsAt %v, want %v", got.StartsAt, ev.Payload.Time)
+	}
+	if got.EndsAt.Before(before.Add(10 * time.Minute)) {
+		t.Errorf("got endsAt %v, want at least 10m after %v", got.EndsAt, before)
+	}
+	if want := "https://falco.example.com/falcoevents/fe-1"; got.GeneratorURL != want {
+		t.Errorf("got generatorURL %q, want %q", got.GeneratorURL, want)
+	}
+
+	// the alert is still active, so a recurrence is not re-sent
+	if err := a.Send(context.Background(), &Event{Name: "fe-1", Payload: ev.Payload}); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-alerts:
+		t.Error("recurrence of an active alert was re-sent")
+	default:
+	}
+
+	// unless it was sent more than half its expiry ago
+	a.active["fe-1"].sentAt = time.Now().Add(-6 * time.Minute)
+	if err := a.Send(context.Background(), &Event{Name: "fe-1", Payload: ev.Payload}); err != nil {
+		t.Fatal(err)
+	}
+	doc = <-alerts
+	if !doc[0].StartsAt.Equal(ev.Payload.Time) {
+		t.Errorf("got startsAt %v for the recurrence, want %v", doc[0].StartsAt, ev.Payload.Time)
+	}
+}
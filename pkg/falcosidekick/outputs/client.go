@@ -0,0 +1,177 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files of the client certificate and CA of the outputs with mutual TLS, in the
+// MutualTLSFilesPath directory.
+const (
+	mutualTLSCertFile = "client.crt"
+	mutualTLSKeyFile  = "client.key"
+	mutualTLSCAFile   = "ca.crt"
+)
+
+const (
+	// maxErrorBody is the number of bytes of an error response kept in the error.
+	maxErrorBody = 512
+	// requestTimeout bounds a request to an output endpoint.
+	requestTimeout = 10 * time.Second
+)
+
+// StatusError is the error of a response other than 2xx.
+type StatusError struct {
+	URL     string
+	Code    int
+	Status  string
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s responded %s: %s", e.URL, e.Status, e.Message)
+}
+
+// Client posts JSON documents to the endpoint of an output.
+type Client struct {
+	url    string
+	header http.Header
+	gzip   bool
+	http   *http.Client
+}
+
+// NewClient returns a client of the endpoint. Without checkCert, the certificate of the
+// endpoint is not verified. With mutualTLS, the client authenticates with the certificate in
+// the mutualTLSPath directory and verifies the endpoint with the CA in it.
+func NewClient(endpoint string, checkCert, mutualTLS bool, mutualTLSPath string) (*Client, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme != "http" && u.Scheme != "https" || u.Host == "" {
+		return nil, fmt.Errorf("invalid url %q", endpoint)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: !checkCert, // nolint:gosec
+	}
+	if mutualTLS {
+		cert, err := tls.LoadX509KeyPair(filepath.Join(mutualTLSPath, mutualTLSCertFile), filepath.Join(mutualTLSPath, mutualTLSKeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the mutual TLS client certificate: %v", err)
+		}
+		ca, err := os.ReadFile(filepath.Join(mutualTLSPath, mutualTLSCAFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the mutual TLS CA: %v", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate found in %s", filepath.Join(mutualTLSPath, mutualTLSCAFile))
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+		tlsConfig.RootCAs = pool
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &Client{
+		url:    u.String(),
+		header: http.Header{"Content-Type": []string{"application/json"}},
+		http:   &http.Client{Transport: transport, Timeout: requestTimeout},
+	}, nil
+}
+
+// SetHeader sets a header of the requests.
+func (c *Client) SetHeader(key, value string) {
+	c.header.Set(key, value)
+}
+
+// SetBasicAuth authenticates the requests with the username and password.
+func (c *Client) SetBasicAuth(username, password string) {
+	c.header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(username+":"+password)))
+}
+
+// EnableGzip compresses the body of the requests with gzip.
+func (c *Client) EnableGzip() {
+	c.gzip = true
+	c.header.Set("Content-Encoding", "gzip")
+}
+
+// Post sends the document as JSON. Responses other than 2xx are a *StatusError.
+func (c *Client) Post(ctx context.Context, doc any) error {
+	return c.Do(ctx, http.MethodPost, doc)
+}
+
+// Do sends the document as JSON with the method. Responses other than 2xx are a *StatusError.
+func (c *Client) Do(ctx context.Context, method string, doc any) error {
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = c.Request(ctx, method, "", "", body)
+	return err
+}
+
+// Request sends the body with the method to the path, relative to the endpoint, and returns the
+// response. Without a contentType, the body is sent as JSON. Responses other than 2xx are a
+// *StatusError.
+func (c *Client) Request(ctx context.Context, method, path, contentType string, body []byte) ([]byte, error) {
+	if c.gzip {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(body); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+		body = buf.Bytes()
+	}
+	u := c.url + path
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range c.header {
+		req.Header[k] = v
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close() // nolint:errcheck
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, &StatusError{URL: u, Code: resp.StatusCode, Status: resp.Status, Message: string(bytes.TrimSpace(msg))}
+	}
+	return io.ReadAll(resp.Body)
+}
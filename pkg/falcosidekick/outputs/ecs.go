@@ -0,0 +1,246 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"encoding/json"
+	"net"
+	"strconv"
+	"strings"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+// ecsDocument returns the event as an Elastic Common Schema document. The fields without an ECS
+// equivalent are under falco.
+func ecsDocument(fe *v1beta1.FalcoEvent) map[string]any {
+	spec := fe.Spec
+	p := types.Priority(string(spec.Priority))
+	event := map[string]any{
+		"kind":     "alert",
+		"category": []string{"intrusion_detection"},
+		"type":     []string{"info"},
+		"module":   "falco",
+		"dataset":  "falco.alerts",
+		"severity": ecsSeverity(p),
+		"created":  fe.CreationTimestamp.UTC(),
+	}
+	if spec.UUID != "" {
+		event["id"] = spec.UUID
+	}
+	doc := map[string]any{
+		"@timestamp": spec.Time.UTC(),
+		"message":    spec.Output,
+		"ecs":        map[string]string{"version": "8.11.0"},
+		"event":      event,
+		"rule":       map[string]string{"name": spec.Rule},
+		"log":        map[string]string{"level": strings.ToLower(p.String())},
+	}
+	if len(spec.Tags) > 0 {
+		doc["tags"] = spec.Tags
+	}
+	node := spec.Nodename
+	if node == "" {
+		node = spec.Hostname
+	}
+	if node != "" {
+		doc["host"] = map[string]string{"name": node, "hostname": spec.Hostname}
+	}
+
+	if w := spec.Workload; w != nil && (w.Namespace != "" || w.Pod != "") {
+		o := map[string]any{"type": "kubernetes"}
+		if w.Namespace != "" {
+			o["namespace"] = w.Namespace
+		}
+		if w.Pod != "" {
+			o["resource"] = map[string]string{"type": "pod", "name": w.Pod}
+		}
+		doc["orchestrator"] = o
+	}
+	if c := spec.Container; c != nil {
+		container := map[string]any{}
+		setString(container, "id", c.ID)
+		setString(container, "name", c.Name)
+		if c.ImageRepository != "" {
+			image := map[string]any{"name": c.ImageRepository}
+			if c.ImageTag != "" {
+				image["tag"] = []string{c.ImageTag}
+			}
+			if c.ImageDigest != "" {
+				image["hash"] = map[string]any{"all": []string{c.ImageDigest}}
+			}
+			container["image"] = image
+		}
+		if c.Privileged != nil {
+			container["security_context"] = map[string]bool{"privileged": *c.Privileged}
+		}
+		if len(container) > 0 {
+			doc["container"] = container
+		}
+	}
+	if pr := spec.Process; pr != nil {
+		process := map[string]any{}
+		setString(process, "name", pr.Name)
+		setString(process, "executable", pr.Executable)
+		setString(process, "command_line", pr.Cmdline)
+		setString(process, "working_directory", pr.Cwd)
+		if pr.PID != nil {
+			process["pid"] = *pr.PID
+		}
+		parent := map[string]any{}
+		setString(parent, "name", pr.ParentName)
+		if pr.ParentPID != nil {
+			parent["pid"] = *pr.ParentPID
+		}
+		if len(parent) > 0 {
+			process["parent"] = parent
+		}
+		if len(process) > 0 {
+			doc["process"] = process
+		}
+		user := map[string]any{}
+		setString(user, "name", pr.User)
+		if pr.UID != nil {
+			user["id"] = strconv.FormatInt(*pr.UID, 10)
+		}
+		if len(user) > 0 {
+			doc["user"] = user
+		}
+	}
+	if n := spec.Network; n != nil {
+		if n.FDType == "file" && n.FDName != "" {
+			doc["file"] = map[string]string{"path": n.FDName}
+		}
+		if n.L4Protocol != "" {
+			doc["network"] = map[string]string{"transport": n.L4Protocol}
+		}
+		if ep := ecsEndpoint(n.ClientIP, n.ClientPort); ep != nil {
+			doc["source"] = ep
+		}
+		if ep := ecsEndpoint(n.ServerIP, n.ServerPort); ep != nil {
+			doc["destination"] = ep
+		}
+	}
+
+	falco := map[string]any{
+		"falcoevent": fe.Name,
+		"priority":   p.String(),
+		"source":     spec.Source,
+	}
+	if len(spec.OutputFields.Raw) > 0 {
+		falco["output_fields"] = json.RawMessage(spec.OutputFields.Raw)
+	}
+	doc["falco"] = falco
+	return doc
+}
+
+// ecsSeverity returns the syslog severity of the priority, from 0 for Emergency to 7 for Debug.
+func ecsSeverity(p types.PriorityType) int {
+	if p == types.Default {
+		return 7
+	}
+	return types.Emergency - int(p)
+}
+
+// ecsEndpoint returns the source or destination of a connection, if the address is an IP.
+func ecsEndpoint(ip string, port *int64) map[string]any {
+	if net.ParseIP(ip) == nil {
+		return nil
+	}
+	ep := map[string]any{"ip": ip}
+	if port != nil {
+		ep["port"] = *port
+	}
+	return ep
+}
+
+func setString(m map[string]any, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
+
+func keyword() map[string]string {
+	return map[string]string{"type": "keyword"}
+}
+
+// ecsMappings are the mappings of the index template, with the types of the Elastic Common
+// Schema. The output fields are stored but not indexed, as their keys are dotted field names
+// that conflict with each other once expanded into objects.
+var ecsMappings = map[string]any{
+	"dynamic": true,
+	"properties": map[string]any{
+		"@timestamp": map[string]string{"type": "date"},
+		"message":    map[string]string{"type": "text"},
+		"tags":       keyword(),
+		"ecs":        object("version", keyword()),
+		"event": object(
+			"kind", keyword(),
+			"category", keyword(),
+			"type", keyword(),
+			"module", keyword(),
+			"dataset", keyword(),
+			"severity", map[string]string{"type": "long"},
+			"id", keyword(),
+			"created", map[string]string{"type": "date"},
+		),
+		"rule": object("name", keyword()),
+		"log":  object("level", keyword()),
+		"host": object("name", keyword(), "hostname", keyword()),
+		"orchestrator": object(
+			"type", keyword(),
+			"namespace", keyword(),
+			"resource", object("type", keyword(), "name", keyword()),
+		),
+		"container": object(
+			"id", keyword(),
+			"name", keyword(),
+			"image", object("name", keyword(), "tag", keyword(), "hash", object("all", keyword())),
+			"security_context", object("privileged", map[string]string{"type": "boolean"}),
+		),
+		"process": object(
+			"name", keyword(),
+			"executable", keyword(),
+			"command_line", keyword(),
+			"working_directory", keyword(),
+			"pid", map[string]string{"type": "long"},
+			"parent", object("name", keyword(), "pid", map[string]string{"type": "long"}),
+		),
+		"user":        object("name", keyword(), "id", keyword()),
+		"file":        object("path", keyword()),
+		"network":     object("transport", keyword()),
+		"source":      object("ip", map[string]string{"type": "ip"}, "port", map[string]string{"type": "long"}),
+		"destination": object("ip", map[string]string{"type": "ip"}, "port", map[string]string{"type": "long"}),
+		"falco": object(
+			"falcoevent", keyword(),
+			"priority", keyword(),
+			"source", keyword(),
+			"output_fields", map[string]any{"type": "object", "enabled": false},
+		),
+	},
+}
+
+// object returns the mapping of an object field with the properties, given as name and mapping
+// pairs.
+func object(properties ...any) map[string]any {
+	props := map[string]any{}
+	for i := 0; i+1 < len(properties); i += 2 {
+		props[properties[i].(string)] = properties[i+1]
+	}
+	return map[string]any{"properties": props}
+}
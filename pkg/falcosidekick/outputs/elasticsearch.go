@@ -0,0 +1,284 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	"k8s.io/klog/v2"
+)
+
+// Suffixes of the indices, by the day, month or year of the events.
+const (
+	ElasticsearchSuffixDaily    = "daily"
+	ElasticsearchSuffixMonthly  = "monthly"
+	ElasticsearchSuffixAnnually = "annually"
+	ElasticsearchSuffixNone     = "none"
+)
+
+const (
+	defaultElasticsearchIndex = "falco"
+	// defaultElasticsearchBatchSize is the maximum bytes of a bulk request without a BatchSize.
+	defaultElasticsearchBatchSize = 5 << 20
+	// defaultElasticsearchBatchWait is the maximum time an event waits without a BatchWaitSeconds.
+	defaultElasticsearchBatchWait = time.Second
+)
+
+// Elasticsearch indexes the events in Elasticsearch or OpenSearch as Elastic Common Schema
+// documents, with the bulk API, in indices suffixed by the date of the events. It only uses the
+// APIs common to Elasticsearch 7.8+ and OpenSearch, so the Type of the configuration is ignored.
+type Elasticsearch struct {
+	cfg         types.ElasticsearchOutputConfig
+	minPriority types.PriorityType
+	batchSize   int
+	batchWait   time.Duration
+	client      *Client
+
+	templateCreated bool
+	// items are the batched actions of the bulk request, each with its document
+	items [][]byte
+	size  int
+}
+
+// bulkError is the error of the items of a bulk request that failed.
+type bulkError struct {
+	failed int
+	// retriable is the number of failed items that are retried
+	retriable int
+	reason    string
+}
+
+func (e *bulkError) Error() string {
+	return fmt.Sprintf("%d documents failed to be indexed: %s", e.failed, e.reason)
+}
+
+var _ BatchOutput = &Elasticsearch{}
+
+func NewElasticsearch(cfg types.ElasticsearchOutputConfig, mutualTLSPath string) (*Elasticsearch, error) {
+	if cfg.Index == "" {
+		cfg.Index = defaultElasticsearchIndex
+	}
+	switch cfg.Suffix {
+	case "":
+		cfg.Suffix = ElasticsearchSuffixDaily
+	case ElasticsearchSuffixDaily, ElasticsearchSuffixMonthly, ElasticsearchSuffixAnnually, ElasticsearchSuffixNone:
+	default:
+		return nil, fmt.Errorf("elasticsearch: unknown suffix %q", cfg.Suffix)
+	}
+	c, err := NewClient(strings.TrimSuffix(cfg.HostPort, "/"), cfg.CheckCert, cfg.MutualTLS, mutualTLSPath)
+	if err != nil {
+		return nil, fmt.Errorf("elasticsearch: %v", err)
+	}
+	for k, v := range cfg.CustomHeaders {
+		c.SetHeader(k, v)
+	}
+	if cfg.Username != "" {
+		c.SetBasicAuth(cfg.Username, cfg.Password)
+	}
+
+	e := &Elasticsearch{
+		cfg:         cfg,
+		minPriority: types.Priority(cfg.MinimumPriority),
+		batchSize:   cfg.BatchSize,
+		batchWait:   time.Duration(cfg.BatchWaitSeconds) * time.Second,
+		client:      c,
+	}
+	if e.batchSize <= 0 {
+		e.batchSize = defaultElasticsearchBatchSize
+	}
+	if e.batchWait <= 0 {
+		e.batchWait = defaultElasticsearchBatchWait
+	}
+	return e, nil
+}
+
+func (e *Elasticsearch) Name() string {
+	return "elasticsearch"
+}
+
+func (e *Elasticsearch) MinimumPriority() types.PriorityType {
+	return e.minPriority
+}
+
+func (e *Elasticsearch) FlushInterval() time.Duration {
+	return e.batchWait
+}
+
+func (e *Elasticsearch) Full() bool {
+	return e.size >= e.batchSize
+}
+
+// index returns the index of the events of the time.
+func (e *Elasticsearch) index(t time.Time) string {
+	t = t.UTC()
+	switch e.cfg.Suffix {
+	case ElasticsearchSuffixMonthly:
+		return e.cfg.Index + "-" + t.Format("2006.01")
+	case ElasticsearchSuffixAnnually:
+		return e.cfg.Index + "-" + t.Format("2006")
+	case ElasticsearchSuffixNone:
+		return e.cfg.Index
+	default:
+		return e.cfg.Index + "-" + t.Format("2006.01.02")
+	}
+}
+
+// Send adds the event to the batch. The document is created with the UID of the FalcoEvent as
+// its id, so a retried request does not index it twice.
+func (e *Elasticsearch) Send(_ context.Context, ev *Event) error {
+	fe := ev.FalcoEvent
+	meta := map[string]string{"_index": e.index(fe.Spec.Time.Time)}
+	if fe.UID != "" {
+		meta["_id"] = string(fe.UID)
+	}
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	if err := enc.Encode(map[string]any{"create": meta}); err != nil {
+		return err
+	}
+	if err := enc.Encode(ecsDocument(fe)); err != nil {
+		return err
+	}
+	e.items = append(e.items, buf.Bytes())
+	e.size += buf.Len()
+	return nil
+}
+
+// Flush indexes the batch. Only the items that failed with a retriable status are retried.
+func (e *Elasticsearch) Flush(ctx context.Context) (int, int, error) {
+	items := e.items
+	total := len(items)
+	if total == 0 {
+		return 0, 0, nil
+	}
+	e.items, e.size = nil, 0
+
+	if e.cfg.CreateIndexTemplate && !e.templateCreated {
+		if err := e.createIndexTemplate(ctx); err != nil {
+			klog.ErrorS(err, "failed to create the index template, the fields are mapped dynamically", "output", e.Name())
+		} else {
+			e.templateCreated = true
+		}
+	}
+
+	var rejected int
+	var lastErr *bulkError
+	err := withRetries(ctx, e.Name(), stats.Elasticsearch, func() error {
+		retry, be, err := e.bulk(ctx, items)
+		if err != nil {
+			return err
+		}
+		items = retry
+		if be == nil {
+			return nil
+		}
+		rejected += be.failed - be.retriable
+		lastErr = be
+		return be
+	})
+	if err == nil && rejected > 0 {
+		err = lastErr
+	}
+	failed := rejected + len(items)
+	return total - failed, failed, err
+}
+
+// bulkResponse is the response of the bulk API, with the result of each item.
+type bulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []map[string]struct {
+		Status int `json:"status"`
+		Error  *struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"error,omitempty"`
+	} `json:"items"`
+}
+
+// bulk sends the items and returns the items to retry and the error of the failed ones. An item
+// already created is not an error.
+func (e *Elasticsearch) bulk(ctx context.Context, items [][]byte) ([][]byte, *bulkError, error) {
+	body := bytes.Join(items, nil)
+	data, err := e.client.Request(ctx, http.MethodPost, "/_bulk", "application/x-ndjson", body)
+	if err != nil {
+		return items, nil, err
+	}
+	var resp bulkResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return items, nil, fmt.Errorf("invalid bulk response: %v", err)
+	}
+	if !resp.Errors {
+		return nil, nil, nil
+	}
+	if len(resp.Items) != len(items) {
+		return items, nil, fmt.Errorf("bulk response has %d items, sent %d", len(resp.Items), len(items))
+	}
+
+	var retry [][]byte
+	var be *bulkError
+	for i, result := range resp.Items {
+		for _, r := range result {
+			if r.Error == nil || r.Status == http.StatusConflict {
+				continue
+			}
+			if be == nil {
+				be = &bulkError{reason: r.Error.Type + ": " + r.Error.Reason}
+			}
+			be.failed++
+			if r.Status == http.StatusTooManyRequests || r.Status >= http.StatusInternalServerError {
+				retry = append(retry, items[i])
+				be.retriable++
+			}
+		}
+	}
+	return retry, be, nil
+}
+
+// createIndexTemplate creates the index template of the indices, mapping the fields of the
+// documents with their Elastic Common Schema types.
+func (e *Elasticsearch) createIndexTemplate(ctx context.Context) error {
+	pattern := e.cfg.Index + "-*"
+	if e.cfg.Suffix == ElasticsearchSuffixNone {
+		pattern = e.cfg.Index
+	}
+	doc := map[string]any{
+		"index_patterns": []string{pattern},
+		"template": map[string]any{
+			"mappings": ecsMappings,
+		},
+		"_meta": map[string]string{"description": "Falco events in Elastic Common Schema"},
+	}
+	body, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	_, err = e.client.Request(ctx, http.MethodPut, "/_index_template/"+e.cfg.Index, "", body)
+	var se *StatusError
+	if errors.As(err, &se) && se.Code == http.StatusNotFound {
+		return fmt.Errorf("composable index templates are not supported: %v", err)
+	}
+	return err
+}
@@ -0,0 +1,162 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	k8stypes "k8s.io/apimachinery/pkg/types"
+)
+
+func TestElasticsearch(t *testing.T) {
+	retryBackoff.Duration = time.Millisecond
+	defer func() { retryBackoff.Duration = time.Second }()
+
+	var mu sync.Mutex
+	var template map[string]any
+	var templatePath string
+	// indexed are the documents created, by id
+	indexed := map[string]map[string]any{}
+	throttled := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			templatePath = r.URL.Path
+			_ = json.NewDecoder(r.Body).Decode(&template)
+			return
+		}
+		if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		// fe-2 is rejected and fe-3 is throttled once
+		var items []map[string]any
+		errors := false
+		sc := bufio.NewScanner(r.Body)
+		for sc.Scan() {
+			var action map[string]map[string]string
+			if err := json.Unmarshal(sc.Bytes(), &action); err != nil {
+				t.Error(err)
+				return
+			}
+			sc.Scan()
+			var doc map[string]any
+			_ = json.Unmarshal(sc.Bytes(), &doc)
+			meta := action["create"]
+			name := doc["falco"].(map[string]any)["falcoevent"].(string)
+			result := map[string]any{"_index": meta["_index"], "status": http.StatusCreated}
+			switch {
+			case name == "fe-2":
+				result["status"] = http.StatusBadRequest
+				result["error"] = map[string]string{"type": "mapper_parsing_exception", "reason": "failed to parse"}
+				errors = true
+			case name == "fe-3" && !throttled:
+				throttled = true
+				result["status"] = http.StatusTooManyRequests
+				result["error"] = map[string]string{"type": "es_rejected_execution_exception", "reason": "rejected"}
+				errors = true
+			default:
+				indexed[meta["_id"]] = doc
+			}
+			items = append(items, map[string]any{"create": result})
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"errors": errors, "items": items})
+	}))
+	defer srv.Close()
+
+	e, err := NewElasticsearch(types.ElasticsearchOutputConfig{
+		HostPort:            srv.URL,
+		Index:               "falco",
+		CreateIndexTemplate: true,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"fe-1", "fe-2", "fe-3"} {
+		ev := newEvent(name, v1beta1.PriorityWarning)
+		ev.FalcoEvent.UID = k8stypes.UID("uid-" + name)
+		ev.FalcoEvent.Spec.Workload = &v1beta1.WorkloadInfo{Namespace: "shop", Pod: "cart-1"}
+		ev.FalcoEvent.Spec.Network = &v1beta1.NetworkInfo{ClientIP: "10.0.0.1", ServerIP: "not-an-ip"}
+		if err := e.Send(context.Background(), ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	sent, failed, err := e.Flush(context.Background())
+	if sent != 2 || failed != 1 {
+		t.Errorf("got %d sent and %d failed, want 2 and 1", sent, failed)
+	}
+	if err == nil {
+		t.Error("expected the error of the rejected document")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if templatePath != "/_index_template/falco" {
+		t.Errorf("got index template path %q", templatePath)
+	}
+	if patterns, _ := template["index_patterns"].([]any); len(patterns) != 1 || patterns[0] != "falco-*" {
+		t.Errorf("got index patterns %v", template["index_patterns"])
+	}
+	if len(indexed) != 2 || indexed["uid-fe-1"] == nil || indexed["uid-fe-3"] == nil {
+		t.Fatalf("got indexed documents %v", indexed)
+	}
+	doc := indexed["uid-fe-1"]
+	var buf bytes.Buffer
+	_ = json.NewEncoder(&buf).Encode(doc)
+	for path, want := range map[string]any{
+		"rule.name":              "Terminal shell in container",
+		"log.level":              "warning",
+		"event.severity":         float64(4),
+		"event.kind":             "alert",
+		"orchestrator.namespace": "shop",
+		"source.ip":              "10.0.0.1",
+		"host.hostname":          "node-1",
+	} {
+		if got := lookupPath(doc, path); got != want {
+			t.Errorf("got %s=%v, want %v in %s", path, got, want, buf.String())
+		}
+	}
+	if _, ok := doc["destination"]; ok {
+		t.Error("destination with an invalid IP is indexed")
+	}
+}
+
+// lookupPath returns the value of the dotted path in the document.
+func lookupPath(doc map[string]any, path string) any {
+	var v any = doc
+	for _, k := range strings.Split(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
@@ -0,0 +1,213 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+const (
+	// DefaultLokiEndpoint is the path of the Loki push API.
+	DefaultLokiEndpoint = "/loki/api/v1/push"
+	// defaultLokiBatchSize is the maximum bytes of the log lines of a push without a BatchSize.
+	defaultLokiBatchSize = 1 << 20
+	// defaultLokiBatchWait is the maximum time an event waits without a BatchWaitSeconds.
+	defaultLokiBatchWait = time.Second
+)
+
+// Loki pushes the events as log lines to the Loki push API, in streams labeled with the rule,
+// priority, source, namespace and node of the events. The pushes are batched and compressed.
+type Loki struct {
+	cfg         types.LokiOutputConfig
+	minPriority types.PriorityType
+	batchSize   int
+	batchWait   time.Duration
+	client      *Client
+
+	// streams are the batched log lines, by their labels
+	streams map[string]*lokiStream
+	size    int
+	events  int
+}
+
+// lokiStream is a stream of the Loki push API.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	// Values are the timestamp in nanoseconds and the log line of the entries.
+	Values [][2]string `json:"values"`
+}
+
+// lokiLine is the log line of an event, the Falco payload and the name of its FalcoEvent.
+type lokiLine struct {
+	FalcoEvent string `json:"falcoevent"`
+	types.FalcoPayload
+}
+
+var _ BatchOutput = &Loki{}
+
+func NewLoki(cfg types.LokiOutputConfig, mutualTLSPath string) (*Loki, error) {
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = DefaultLokiEndpoint
+	}
+	c, err := NewClient(strings.TrimSuffix(cfg.HostPort, "/")+cfg.Endpoint, cfg.CheckCert, cfg.MutualTLS, mutualTLSPath)
+	if err != nil {
+		return nil, fmt.Errorf("loki: %v", err)
+	}
+	c.EnableGzip()
+	for k, v := range cfg.CustomHeaders {
+		c.SetHeader(k, v)
+	}
+	if cfg.Tenant != "" {
+		c.SetHeader("X-Scope-OrgID", cfg.Tenant)
+	}
+	switch {
+	case cfg.User != "" && cfg.APIKey != "":
+		c.SetBasicAuth(cfg.User, cfg.APIKey)
+	case cfg.APIKey != "":
+		c.SetHeader("Authorization", "Bearer "+cfg.APIKey)
+	}
+
+	l := &Loki{
+		cfg:         cfg,
+		minPriority: types.Priority(cfg.MinimumPriority),
+		batchSize:   cfg.BatchSize,
+		batchWait:   time.Duration(cfg.BatchWaitSeconds) * time.Second,
+		client:      c,
+		streams:     map[string]*lokiStream{},
+	}
+	if l.batchSize <= 0 {
+		l.batchSize = defaultLokiBatchSize
+	}
+	if l.batchWait <= 0 {
+		l.batchWait = defaultLokiBatchWait
+	}
+	return l, nil
+}
+
+func (l *Loki) Name() string {
+	return "loki"
+}
+
+func (l *Loki) MinimumPriority() types.PriorityType {
+	return l.minPriority
+}
+
+func (l *Loki) FlushInterval() time.Duration {
+	return l.batchWait
+}
+
+func (l *Loki) Full() bool {
+	return l.size >= l.batchSize
+}
+
+// Send adds the event to the batch.
+func (l *Loki) Send(_ context.Context, ev *Event) error {
+	line, err := json.Marshal(lokiLine{FalcoEvent: ev.Name, FalcoPayload: ev.Payload})
+	if err != nil {
+		return err
+	}
+	labels := l.labels(ev)
+	key := streamKey(labels)
+	s, ok := l.streams[key]
+	if !ok {
+		s = &lokiStream{Stream: labels}
+		l.streams[key] = s
+	}
+	ts := ev.Payload.Time
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	s.Values = append(s.Values, [2]string{strconv.FormatInt(ts.UnixNano(), 10), string(line)})
+	l.size += len(line)
+	l.events++
+	return nil
+}
+
+// Flush pushes the batch. The batch is dropped once the attempts are exhausted.
+func (l *Loki) Flush(ctx context.Context) (int, int, error) {
+	n := l.events
+	if n == 0 {
+		return 0, 0, nil
+	}
+	doc := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{Streams: make([]*lokiStream, 0, len(l.streams))}
+	for _, s := range l.streams {
+		doc.Streams = append(doc.Streams, s)
+	}
+	l.streams = map[string]*lokiStream{}
+	l.size, l.events = 0, 0
+
+	err := withRetries(ctx, l.Name(), stats.Loki, func() error {
+		return l.client.Post(ctx, doc)
+	})
+	if err != nil {
+		return 0, n, err
+	}
+	return n, 0, nil
+}
+
+func (l *Loki) labels(ev *Event) map[string]string {
+	p := ev.Payload
+	labels := map[string]string{}
+	for _, f := range l.cfg.ExtraLabelsList {
+		if v, ok := p.OutputFields[f]; ok && v != nil {
+			if s := fmt.Sprint(v); s != "" {
+				labels[labelName(f)] = s
+			}
+		}
+	}
+	labels["rule"] = p.Rule
+	labels["priority"] = p.Priority.String()
+	labels["source"] = p.Source
+	if ns, _ := p.OutputFields["k8s.ns.name"].(string); ns != "" {
+		labels["namespace"] = ns
+	}
+	node, _ := p.OutputFields["k8s.node.name"].(string)
+	if node == "" {
+		node = p.Hostname
+	}
+	if node != "" {
+		labels["node"] = node
+	}
+	return labels
+}
+
+// streamKey returns the labels in a canonical form, identifying their stream.
+func streamKey(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		sb.WriteString(k)
+		sb.WriteByte('=')
+		sb.WriteString(strconv.Quote(labels[k]))
+		sb.WriteByte(',')
+	}
+	return sb.String()
+}
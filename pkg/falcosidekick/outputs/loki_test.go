@@ -0,0 +1,121 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+func TestLoki(t *testing.T) {
+	var header http.Header
+	var path string
+	var doc struct {
+		Streams []lokiStream `json:"streams"`
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header, path = r.Header, r.URL.Path
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		if err := json.NewDecoder(zr).Decode(&doc); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	l, err := NewLoki(types.LokiOutputConfig{
+		HostPort:        srv.URL,
+		Tenant:          "falco",
+		User:            "user",
+		APIKey:          "secret",
+		ExtraLabelsList: []string{"k8s.pod.name"},
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fields := map[string]any{"k8s.ns.name": "shop", "k8s.node.name": "node-1", "k8s.pod.name": "cart-1"}
+	for _, name := range []string{"fe-1", "fe-2"} {
+		ev := newEvent(name, v1beta1.PriorityWarning)
+		ev.Payload.Source = "syscall"
+		ev.Payload.OutputFields = fields
+		if err := l.Send(context.Background(), ev); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if l.Full() {
+		t.Error("batch of two events is full")
+	}
+	sent, _, err := l.Flush(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sent != 2 {
+		t.Errorf("flushed %d events, want 2", sent)
+	}
+	if path != DefaultLokiEndpoint {
+		t.Errorf("got path %q, want %q", path, DefaultLokiEndpoint)
+	}
+	if got := header.Get("X-Scope-OrgID"); got != "falco" {
+		t.Errorf("got tenant %q, want falco", got)
+	}
+	if got := header.Get("Authorization"); got != "Basic dXNlcjpzZWNyZXQ=" {
+		t.Errorf("got authorization %q", got)
+	}
+	if len(doc.Streams) != 1 {
+		t.Fatalf("got %d streams, want 1", len(doc.Streams))
+	}
+	s := doc.Streams[0]
+	for k, v := range map[string]string{
+		"rule":         "Terminal shell in container",
+		"priority":     "Warning",
+		"source":       "syscall",
+		"namespace":    "shop",
+		"node":         "node-1",
+		"k8s_pod_name": "cart-1",
+	} {
+		if s.Stream[k] != v {
+			t.Errorf("got label %s=%q, want %q", k, s.Stream[k], v)
+		}
+	}
+	if len(s.Values) != 2 {
+		t.Fatalf("got %d entries, want 2", len(s.Values))
+	}
+	var line lokiLine
+	if err := json.Unmarshal([]byte(s.Values[1][1]), &line); err != nil {
+		t.Fatal(err)
+	}
+	if line.FalcoEvent != "fe-2" || line.Rule != "Terminal shell in container" {
+		t.Errorf("got log line %s", s.Values[1][1])
+	}
+
+	// the batch is empty after a flush
+	if sent, failed, err := l.Flush(context.Background()); sent+failed != 0 || err != nil {
+		t.Errorf("flushed %d events of an empty batch, err %v", sent+failed, err)
+	}
+}
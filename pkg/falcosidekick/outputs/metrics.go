@@ -0,0 +1,53 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricPrefix = "falco_appscode_com_"
+
+// Statuses of the events handled by an output.
+const (
+	statusSent    = "sent"
+	statusFailed  = "failed"
+	statusDropped = "dropped"
+)
+
+var (
+	outputEvents = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricPrefix + "output_events_total",
+		Help: "Number of FalcoEvents handled by the outputs, by status",
+	}, []string{"output", "status"})
+
+	outputAttempts = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricPrefix + "output_attempts_total",
+		Help: "Number of delivery attempts of the outputs, including the retries, by status",
+	}, []string{"output", "status"})
+
+	sendLatency = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    metricPrefix + "output_send_latency_seconds",
+		Help:    "Duration of the delivery of a FalcoEvent, or a batch of them, to an output",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"output"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(outputEvents, outputAttempts, sendLatency)
+}
@@ -0,0 +1,300 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	"k8s.io/klog/v2"
+)
+
+const (
+	// queueSize is the number of events queued per output. Events are dropped while the queue is full.
+	queueSize = 1000
+	// sendTimeout bounds the delivery of an event to an output, including the retries.
+	sendTimeout = 2 * time.Minute
+	// shutdownTimeout bounds the last flush of the batched events on shutdown. It is shorter
+	// than the graceful shutdown period of the manager.
+	shutdownTimeout = 10 * time.Second
+)
+
+// Event is a Falco payload accepted by the ingest handler and the FalcoEvent storing it.
+type Event struct {
+	// Name is the name of the FalcoEvent.
+	Name string
+	// FalcoEvent is the FalcoEvent created for the payload. It is nil for the recurrences of a
+	// stored FalcoEvent.
+	FalcoEvent *v1beta1.FalcoEvent
+	Payload    types.FalcoPayload
+}
+
+// Recurrence returns true if the payload recurs a stored FalcoEvent.
+func (e *Event) Recurrence() bool {
+	return e.FalcoEvent == nil
+}
+
+// Output sends the new FalcoEvents to an external system.
+type Output interface {
+	Name() string
+	// MinimumPriority is the lowest priority of the events sent to the output.
+	MinimumPriority() types.PriorityType
+	Send(ctx context.Context, ev *Event) error
+}
+
+// RecurrenceOutput is an Output that is also sent the recurrences of the stored FalcoEvents.
+type RecurrenceOutput interface {
+	Output
+	SendRecurrences() bool
+}
+
+func sendsRecurrences(o Output) bool {
+	ro, ok := o.(RecurrenceOutput)
+	return ok && ro.SendRecurrences()
+}
+
+// BatchOutput is an Output sending the events in batches. Send adds the event to the batch,
+// which is flushed when full or after the flush interval.
+type BatchOutput interface {
+	Output
+	// Full returns true if the batch is to be flushed without waiting for the interval.
+	Full() bool
+	// FlushInterval is the longest time an event waits in the batch.
+	FlushInterval() time.Duration
+	// Flush sends the batch and returns the number of events sent and failed.
+	Flush(ctx context.Context) (sent, failed int, err error)
+}
+
+// Options are the settings shared by the outputs.
+type Options struct {
+	// UIURL is the base URL of the UI the outputs link the events to. If empty, the outputs show
+	// the kubectl command getting the event instead.
+	UIURL string
+}
+
+// Link returns the URL of the event in the UI, or an empty string without a UI.
+func (o Options) Link(name string) string {
+	if o.UIURL == "" {
+		return ""
+	}
+	return strings.TrimSuffix(o.UIURL, "/") + "/falcoevents/" + url.PathEscape(name)
+}
+
+// KubectlCommand returns the kubectl command getting the event.
+func KubectlCommand(name string) string {
+	return fmt.Sprintf("kubectl get falcoevents.%s %s -o yaml", v1beta1.SchemeGroupVersion.Group, name)
+}
+
+// New returns the outputs enabled by the configuration.
+func New(cfg *types.Configuration, opts Options) ([]Output, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	var out []Output
+	if cfg.Slack.WebhookURL != "" {
+		o, err := NewSlack(cfg.Slack, cfg.MutualTLSFilesPath, opts)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if cfg.Webhook.Address != "" {
+		o, err := NewWebhook(cfg.Webhook, cfg.MutualTLSFilesPath)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if cfg.Alertmanager.HostPort != "" {
+		o, err := NewAlertmanager(cfg.Alertmanager, cfg.MutualTLSFilesPath, opts)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if cfg.Loki.HostPort != "" {
+		o, err := NewLoki(cfg.Loki, cfg.MutualTLSFilesPath)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	if cfg.Elasticsearch.HostPort != "" {
+		o, err := NewElasticsearch(cfg.Elasticsearch, cfg.MutualTLSFilesPath)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, o)
+	}
+	return out, nil
+}
+
+type queue struct {
+	output Output
+	events chan *Event
+}
+
+// Dispatcher queues the new events and sends them to the outputs in the background, so the
+// ingest path does not wait for the outputs. A nil Dispatcher drops the events.
+type Dispatcher struct {
+	queues []queue
+}
+
+func NewDispatcher(outputs ...Output) *Dispatcher {
+	d := &Dispatcher{}
+	for _, o := range outputs {
+		d.queues = append(d.queues, queue{output: o, events: make(chan *Event, queueSize)})
+	}
+	return d
+}
+
+// Enqueue queues the event for the outputs its priority is high enough for. Recurrences are
+// only queued for the outputs sending them.
+func (d *Dispatcher) Enqueue(ev *Event) {
+	if d == nil {
+		return
+	}
+	for _, q := range d.queues {
+		if ev.Payload.Priority < q.output.MinimumPriority() || ev.Recurrence() && !sendsRecurrences(q.output) {
+			continue
+		}
+		select {
+		case q.events <- ev:
+		default:
+			outputEvents.WithLabelValues(q.output.Name(), statusDropped).Inc()
+		}
+	}
+}
+
+// NeedLeaderElection returns false, as every replica sends the events it stores.
+func (d *Dispatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start sends the queued events until the context is done. It implements manager.Runnable.
+// It returns once the batched events are flushed.
+func (d *Dispatcher) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for _, q := range d.queues {
+		klog.InfoS("Starts output", "output", q.output.Name())
+		wg.Add(1)
+		go func(q queue) {
+			defer wg.Done()
+			q.run(ctx)
+		}(q)
+	}
+	wg.Wait()
+	return nil
+}
+
+func (q queue) run(ctx context.Context) {
+	bo, ok := q.output.(BatchOutput)
+	if !ok {
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case ev := <-q.events:
+				q.send(ctx, ev)
+			}
+		}
+	}
+
+	ticker := time.NewTicker(bo.FlushInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			q.shutdown(bo)
+			return
+		case ev := <-q.events:
+			if q.batch(ctx, bo, ev) && bo.Full() {
+				q.flush(ctx, bo)
+			}
+		case <-ticker.C:
+			q.flush(ctx, bo)
+		}
+	}
+}
+
+// batch adds the event to the batch of the output and returns true on success.
+func (q queue) batch(ctx context.Context, bo BatchOutput, ev *Event) bool {
+	if err := bo.Send(ctx, ev); err != nil {
+		outputEvents.WithLabelValues(bo.Name(), statusFailed).Inc()
+		klog.ErrorS(err, "failed to batch falco event", "output", bo.Name(), "falcoevent", ev.Name)
+		return false
+	}
+	return true
+}
+
+// shutdown adds the queued events to the batch and flushes it once. The context of the
+// dispatcher is done, so the flush gets its own short timeout.
+func (q queue) shutdown(bo BatchOutput) {
+	ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	for {
+		select {
+		case ev := <-q.events:
+			q.batch(ctx, bo, ev)
+		default:
+			q.flush(ctx, bo)
+			return
+		}
+	}
+}
+
+func (q queue) send(ctx context.Context, ev *Event) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	name := q.output.Name()
+	start := time.Now()
+	err := q.output.Send(ctx, ev)
+	sendLatency.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	if err != nil {
+		outputEvents.WithLabelValues(name, statusFailed).Inc()
+		klog.ErrorS(err, "failed to send falco event", "output", name, "falcoevent", ev.Name)
+		return
+	}
+	outputEvents.WithLabelValues(name, statusSent).Inc()
+}
+
+// flush sends the batch of the output, if any.
+func (q queue) flush(ctx context.Context, bo BatchOutput) {
+	ctx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+
+	name := bo.Name()
+	start := time.Now()
+	sent, failed, err := bo.Flush(ctx)
+	if sent+failed == 0 {
+		return
+	}
+	sendLatency.WithLabelValues(name).Observe(time.Since(start).Seconds())
+	outputEvents.WithLabelValues(name, statusSent).Add(float64(sent))
+	if failed > 0 {
+		outputEvents.WithLabelValues(name, statusFailed).Add(float64(failed))
+		klog.ErrorS(err, "failed to send falco events", "output", name, "failed", failed, "sent", sent)
+	}
+}
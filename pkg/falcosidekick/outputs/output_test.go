@@ -0,0 +1,71 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+type fakeBatchOutput struct {
+	batch   []*Event
+	flushed [][]*Event
+	errs    []error
+}
+
+func (o *fakeBatchOutput) Name() string                        { return "fake" }
+func (o *fakeBatchOutput) MinimumPriority() types.PriorityType { return types.Default }
+func (o *fakeBatchOutput) Full() bool                          { return false }
+func (o *fakeBatchOutput) FlushInterval() time.Duration        { return time.Hour }
+
+func (o *fakeBatchOutput) Send(_ context.Context, ev *Event) error {
+	o.batch = append(o.batch, ev)
+	return nil
+}
+
+func (o *fakeBatchOutput) Flush(ctx context.Context) (int, int, error) {
+	n := len(o.batch)
+	o.flushed = append(o.flushed, o.batch)
+	o.errs = append(o.errs, ctx.Err())
+	o.batch = nil
+	return n, 0, nil
+}
+
+func TestDispatcherShutdown(t *testing.T) {
+	o := &fakeBatchOutput{}
+	d := NewDispatcher(o)
+	for _, name := range []string{"fe-1", "fe-2", "fe-3"} {
+		d.Enqueue(newEvent(name, v1beta1.PriorityWarning))
+	}
+
+	// the queued events are batched and flushed once, although the context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := d.Start(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(o.flushed) != 1 || len(o.flushed[0]) != 3 {
+		t.Fatalf("got flushes %v, want one of 3 events", o.flushed)
+	}
+	if o.errs[0] != nil {
+		t.Errorf("flushed with a done context: %v", o.errs[0])
+	}
+}
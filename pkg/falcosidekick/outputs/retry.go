@@ -0,0 +1,130 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Keys of the expvar maps of the outputs, the same as falcosidekick.
+const (
+	statTotal = "total"
+	statOK    = "ok"
+	statError = "error"
+)
+
+// maxAttempts is the number of attempts to deliver an event, including the first one.
+const maxAttempts = 5
+
+// retryBackoff is the backoff between the attempts to deliver an event.
+var retryBackoff = wait.Backoff{
+	Duration: time.Second,
+	Factor:   2,
+	Jitter:   0.5,
+	Steps:    maxAttempts,
+	Cap:      30 * time.Second,
+}
+
+// stats are the delivery attempts of the outputs, published as expvars.
+var stats = &types.Statistics{
+	Slack:         newStatsMap("slack"),
+	Webhook:       newStatsMap("webhook"),
+	Alertmanager:  newStatsMap("alertmanager"),
+	Loki:          newStatsMap("loki"),
+	Elasticsearch: newStatsMap("elasticsearch"),
+}
+
+// statsPrefix prefixes the names of the expvar maps of the outputs.
+const statsPrefix = "outputs."
+
+func newStatsMap(output string) *expvar.Map {
+	return expvar.NewMap(statsPrefix + output).Init()
+}
+
+// StatsHandler serves the expvar maps of the outputs as JSON, in the format of expvar.Handler.
+// The other expvars, eg, the command line holding secrets, are not served.
+func StatsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Please send with get http method", http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		fmt.Fprintf(w, "{\n")
+		first := true
+		expvar.Do(func(kv expvar.KeyValue) {
+			if !strings.HasPrefix(kv.Key, statsPrefix) {
+				return
+			}
+			if !first {
+				fmt.Fprintf(w, ",\n")
+			}
+			first = false
+			fmt.Fprintf(w, "%q: %s", kv.Key, kv.Value)
+		})
+		fmt.Fprintf(w, "\n}\n")
+	})
+}
+
+// withRetries calls send until it succeeds, fails with an error that is not retriable, or the
+// attempts are exhausted. Every attempt is reported in the expvar map and the attempt counter
+// of the output.
+func withRetries(ctx context.Context, output string, m *expvar.Map, send func() error) error {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := send()
+		status := statOK
+		if err != nil {
+			status = statError
+		}
+		m.Add(statTotal, 1)
+		m.Add(status, 1)
+		outputAttempts.WithLabelValues(output, status).Inc()
+
+		if err == nil || !retriable(err) || attempt >= maxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff.Step()):
+		}
+	}
+}
+
+// retriable returns false for the responses the endpoint rejected the document with.
+func retriable(err error) bool {
+	var se *StatusError
+	if errors.As(err, &se) {
+		return se.Code == http.StatusTooManyRequests || se.Code >= http.StatusInternalServerError
+	}
+	var be *bulkError
+	if errors.As(err, &be) {
+		return be.retriable > 0
+	}
+	return true
+}
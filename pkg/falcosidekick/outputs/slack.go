@@ -0,0 +1,187 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+	"kubeops.dev/falco-ui-server/pkg/index"
+)
+
+// Output formats of the Slack messages.
+const (
+	SlackOutputFormatAll    = "all"
+	SlackOutputFormatText   = "text"
+	SlackOutputFormatFields = "fields"
+)
+
+// priorityColors are the colours of the priorities in the messages, the same as falcosidekick.
+var priorityColors = map[types.PriorityType]string{
+	types.Emergency:     "#e20b0b",
+	types.Alert:         "#ff5400",
+	types.Critical:      "#ff5400",
+	types.Error:         "#e20b0b",
+	types.Warning:       "#ffc700",
+	types.Notice:        "#5bffb5",
+	types.Informational: "#68c2ff",
+	types.Debug:         "#ccfff2",
+}
+
+// Slack posts the new events to a Slack incoming webhook. A message has the rule, the priority
+// colour, the namespace, pod and workload of the event and a link to it.
+type Slack struct {
+	cfg         types.SlackOutputConfig
+	opts        Options
+	minPriority types.PriorityType
+	client      *Client
+}
+
+var _ Output = &Slack{}
+
+func NewSlack(cfg types.SlackOutputConfig, mutualTLSPath string, opts Options) (*Slack, error) {
+	switch cfg.OutputFormat {
+	case "":
+		cfg.OutputFormat = SlackOutputFormatAll
+	case SlackOutputFormatAll, SlackOutputFormatText, SlackOutputFormatFields:
+	default:
+		return nil, fmt.Errorf("unknown slack output format %q", cfg.OutputFormat)
+	}
+	c, err := NewClient(cfg.WebhookURL, cfg.CheckCert, cfg.MutualTLS, mutualTLSPath)
+	if err != nil {
+		return nil, fmt.Errorf("slack: %v", err)
+	}
+	return &Slack{
+		cfg:         cfg,
+		opts:        opts,
+		minPriority: types.Priority(cfg.MinimumPriority),
+		client:      c,
+	}, nil
+}
+
+func (s *Slack) Name() string {
+	return "slack"
+}
+
+func (s *Slack) MinimumPriority() types.PriorityType {
+	return s.minPriority
+}
+
+func (s *Slack) Send(ctx context.Context, ev *Event) error {
+	msg, err := s.message(ev)
+	if err != nil {
+		return err
+	}
+	return withRetries(ctx, s.Name(), stats.Slack, func() error {
+		return s.client.Post(ctx, msg)
+	})
+}
+
+type slackMessage struct {
+	Channel     string            `json:"channel,omitempty"`
+	Username    string            `json:"username,omitempty"`
+	IconURL     string            `json:"icon_url,omitempty"`
+	Text        string            `json:"text,omitempty"`
+	Attachments []slackAttachment `json:"attachments"`
+}
+
+type slackAttachment struct {
+	Color    string       `json:"color"`
+	Fallback string       `json:"fallback"`
+	Blocks   []slackBlock `json:"blocks"`
+}
+
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Fields   []slackText `json:"fields,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+func mrkdwn(s string) slackText {
+	return slackText{Type: "mrkdwn", Text: s}
+}
+
+// slackEscape escapes the control characters of the Slack mrkdwn format.
+var slackEscape = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (s *Slack) message(ev *Event) (*slackMessage, error) {
+	fe := ev.FalcoEvent
+	msg := &slackMessage{
+		Channel:  s.cfg.Channel,
+		Username: s.cfg.Username,
+		IconURL:  s.cfg.Icon,
+	}
+	if s.cfg.MessageFormatTemplate != nil {
+		var buf bytes.Buffer
+		if err := s.cfg.MessageFormatTemplate.Execute(&buf, ev.Payload); err != nil {
+			return nil, fmt.Errorf("failed to execute the slack message format: %v", err)
+		}
+		msg.Text = buf.String()
+	}
+
+	title := fmt.Sprintf("*%s* (%s)", slackEscape.Replace(fe.Spec.Rule), fe.Spec.Priority)
+	blocks := []slackBlock{{Type: "section", Text: &slackText{Type: "mrkdwn", Text: title}}}
+	if s.cfg.OutputFormat != SlackOutputFormatFields {
+		blocks = append(blocks, slackBlock{Type: "section", Text: &slackText{Type: "mrkdwn", Text: "```" + slackEscape.Replace(fe.Spec.Output) + "```"}})
+	}
+	if s.cfg.OutputFormat != SlackOutputFormatText {
+		fields := []slackText{
+			mrkdwn("*Source*\n" + slackEscape.Replace(fe.Spec.Source)),
+			mrkdwn("*Hostname*\n" + slackEscape.Replace(fe.Spec.Hostname)),
+		}
+		if ns, pod := fe.Labels["k8s.ns.name"], fe.Labels["k8s.pod.name"]; ns != "" {
+			fields = append(fields,
+				mrkdwn("*Namespace*\n"+slackEscape.Replace(ns)),
+				mrkdwn("*Pod*\n"+slackEscape.Replace(pod)),
+				mrkdwn("*Workload*\n"+slackEscape.Replace(index.WorkloadName(pod))),
+			)
+		}
+		if node := fe.Labels["k8s.node.name"]; node != "" {
+			fields = append(fields, mrkdwn("*Node*\n"+slackEscape.Replace(node)))
+		}
+		if len(fe.Spec.Tags) > 0 {
+			fields = append(fields, mrkdwn("*Tags*\n"+slackEscape.Replace(strings.Join(fe.Spec.Tags, ", "))))
+		}
+		blocks = append(blocks, slackBlock{Type: "section", Fields: fields})
+	}
+
+	link := fmt.Sprintf("`%s`", KubectlCommand(fe.Name))
+	if u := s.opts.Link(fe.Name); u != "" {
+		link = fmt.Sprintf("<%s|%s>", u, fe.Name)
+	}
+	elements := []slackText{mrkdwn(link), mrkdwn(fe.Spec.Time.UTC().Format("2006-01-02 15:04:05 MST"))}
+	if s.cfg.Footer != "" {
+		elements = append(elements, mrkdwn(slackEscape.Replace(s.cfg.Footer)))
+	}
+	blocks = append(blocks, slackBlock{Type: "context", Elements: elements})
+
+	msg.Attachments = []slackAttachment{{
+		Color:    priorityColors[types.Priority(string(fe.Spec.Priority))],
+		Fallback: fmt.Sprintf("%s (%s): %s", fe.Spec.Rule, fe.Spec.Priority, fe.Spec.Output),
+		Blocks:   blocks,
+	}}
+	return msg, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEvent(name string, priority v1beta1.Priority) *Event {
+	return &Event{
+		Name: name,
+		FalcoEvent: &v1beta1.FalcoEvent{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: name,
+				Labels: map[string]string{
+					"k8s.ns.name":   "shop",
+					"k8s.pod.name":  "cart-7d4b9c8f6d-x2x9z",
+					"k8s.node.name": "node-1",
+				},
+			},
+			Spec: v1beta1.FalcoEventSpec{
+				Rule:     "Terminal shell in container",
+				Priority: priority,
+				Output:   "A shell was spawned <bash>",
+				Source:   "syscalls",
+				Hostname: "node-1",
+				Time:     metav1.NewMicroTime(time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)),
+			},
+		},
+		Payload: types.FalcoPayload{Rule: "Terminal shell in container", Priority: types.Priority(string(priority))},
+	}
+}
+
+func TestSlack(t *testing.T) {
+	bodies := make(chan []byte, 10)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies <- b
+	}))
+	defer srv.Close()
+
+	s, err := NewSlack(types.SlackOutputConfig{
+		WebhookURL:      srv.URL,
+		Channel:         "#alerts",
+		MinimumPriority: "error",
+	}, "", Options{UIURL: "https://falco.example.com/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	d := NewDispatcher(s)
+	d.Enqueue(newEvent("fe-1", v1beta1.PriorityWarning))
+	d.Enqueue(newEvent("fe-2", v1beta1.PriorityCritical))
+	if n := len(d.queues[0].events); n != 1 {
+		t.Fatalf("queued %d events, want only the one above the minimum priority", n)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() { _ = d.Start(ctx) }()
+
+	var body []byte
+	select {
+	case body = <-bodies:
+	case <-time.After(5 * time.Second):
+		t.Fatal("no message posted")
+	}
+	var msg slackMessage
+	if err := json.Unmarshal(body, &msg); err != nil {
+		t.Fatal(err)
+	}
+	if msg.Channel != "#alerts" || len(msg.Attachments) != 1 {
+		t.Fatalf("unexpected message %s", body)
+	}
+	if a := msg.Attachments[0]; a.Color != priorityColors[types.Critical] {
+		t.Errorf("color = %q, want the critical color", a.Color)
+	}
+	var texts []string
+	for _, b := range msg.Attachments[0].Blocks {
+		if b.Text != nil {
+			texts = append(texts, b.Text.Text)
+		}
+		for _, f := range append(b.Fields, b.Elements...) {
+			texts = append(texts, f.Text)
+		}
+	}
+	for _, want := range []string{
+		"*Terminal shell in container* (Critical)",
+		"```A shell was spawned &lt;bash&gt;```",
+		"*Workload*\ncart",
+		"<https://falco.example.com/falcoevents/fe-2|fe-2>",
+	} {
+		if !slices.Contains(texts, want) {
+			t.Errorf("missing %q in %q", want, texts)
+		}
+	}
+}
+
+func TestSlackError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid_payload", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	s, err := NewSlack(types.SlackOutputConfig{WebhookURL: srv.URL, OutputFormat: SlackOutputFormatText}, "", Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(context.Background(), newEvent("fe-1", v1beta1.PriorityCritical)); err == nil || !strings.Contains(err.Error(), "invalid_payload") {
+		t.Errorf("err = %v, want the response of the webhook", err)
+	}
+}
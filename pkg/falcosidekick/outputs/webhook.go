@@ -0,0 +1,140 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Documents the webhook output can post.
+const (
+	// WebhookFormatFalco posts the Falco payload, as received by the ingest handler.
+	WebhookFormatFalco = "falco"
+	// WebhookFormatFalcoEvent posts the FalcoEvent object. A recurrence of a stored FalcoEvent is
+	// posted as a FalcoEvent of the same name, with the spec of the recurring payload and the
+	// recurrence annotation.
+	WebhookFormatFalcoEvent = "falcoevent"
+)
+
+// Webhook posts every accepted event to an HTTP endpoint, with the configured headers,
+// including the recurrences of the stored events. Failed deliveries are retried with an
+// exponential backoff.
+type Webhook struct {
+	cfg         types.WebhookOutputConfig
+	minPriority types.PriorityType
+	client      *Client
+}
+
+var _ RecurrenceOutput = &Webhook{}
+
+func NewWebhook(cfg types.WebhookOutputConfig, mutualTLSPath string) (*Webhook, error) {
+	switch cfg.Method {
+	case "":
+		cfg.Method = http.MethodPost
+	case http.MethodPost, http.MethodPut:
+	default:
+		return nil, fmt.Errorf("webhook: unsupported method %q", cfg.Method)
+	}
+	switch cfg.Format {
+	case "":
+		cfg.Format = WebhookFormatFalco
+	case WebhookFormatFalco, WebhookFormatFalcoEvent:
+	default:
+		return nil, fmt.Errorf("webhook: unknown format %q", cfg.Format)
+	}
+	c, err := NewClient(cfg.Address, cfg.CheckCert, cfg.MutualTLS, mutualTLSPath)
+	if err != nil {
+		return nil, fmt.Errorf("webhook: %v", err)
+	}
+	for k, v := range cfg.CustomHeaders {
+		c.SetHeader(k, v)
+	}
+	return &Webhook{
+		cfg:         cfg,
+		minPriority: types.Priority(cfg.MinimumPriority),
+		client:      c,
+	}, nil
+}
+
+func (w *Webhook) Name() string {
+	return "webhook"
+}
+
+func (w *Webhook) MinimumPriority() types.PriorityType {
+	return w.minPriority
+}
+
+// SendRecurrences returns true, every event accepted by the ingest handler is posted.
+func (w *Webhook) SendRecurrences() bool {
+	return true
+}
+
+func (w *Webhook) Send(ctx context.Context, ev *Event) error {
+	var doc any = ev.Payload
+	if w.cfg.Format == WebhookFormatFalcoEvent {
+		doc = ev.FalcoEvent
+		if ev.Recurrence() {
+			fe, err := recurrence(ev)
+			if err != nil {
+				return fmt.Errorf("webhook: %v", err)
+			}
+			doc = fe
+		}
+	}
+	return withRetries(ctx, w.Name(), stats.Webhook, func() error {
+		return w.client.Do(ctx, w.cfg.Method, doc)
+	})
+}
+
+// recurrence returns the FalcoEvent posted for a recurrence of a stored FalcoEvent. Its status is
+// left empty, the occurrences are counted by the stored FalcoEvent.
+func recurrence(ev *Event) (*v1beta1.FalcoEvent, error) {
+	fields, err := json.Marshal(ev.Payload.OutputFields)
+	if err != nil {
+		return nil, err
+	}
+	return &v1beta1.FalcoEvent{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: v1beta1.SchemeGroupVersion.String(),
+			Kind:       v1beta1.ResourceKindFalcoEvent,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        ev.Name,
+			Annotations: map[string]string{v1beta1.AnnotationRecurrence: "true"},
+		},
+		Spec: v1beta1.FalcoEventSpec{
+			UUID:         ev.Payload.UUID,
+			Output:       ev.Payload.Output,
+			Priority:     v1beta1.Priority(ev.Payload.Priority.String()),
+			Rule:         ev.Payload.Rule,
+			Time:         metav1.NewMicroTime(ev.Payload.Time),
+			OutputFields: apiextensionsv1.JSON{Raw: fields},
+			Source:       ev.Payload.Source,
+			Tags:         ev.Payload.Tags,
+			Hostname:     ev.Payload.Hostname,
+		},
+	}, nil
+}
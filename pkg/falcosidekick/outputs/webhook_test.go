@@ -0,0 +1,133 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package outputs
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+)
+
+func statValue(m *expvar.Map, key string) int64 {
+	if v, ok := m.Get(key).(*expvar.Int); ok {
+		return v.Value()
+	}
+	return 0
+}
+
+func TestWebhook(t *testing.T) {
+	retryBackoff.Duration = time.Millisecond
+	defer func() { retryBackoff.Duration = time.Second }()
+
+	var requests atomic.Int32
+	var body []byte
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the first two attempts fail
+		if requests.Add(1) <= 2 {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer srv.Close()
+
+	w, err := NewWebhook(types.WebhookOutputConfig{
+		Address:       srv.URL,
+		CustomHeaders: map[string]string{"Authorization": "Bearer secret"},
+		Format:        WebhookFormatFalcoEvent,
+	}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	total, failed := statValue(stats.Webhook, statTotal), statValue(stats.Webhook, statError)
+	if err := w.Send(context.Background(), newEvent("fe-1", v1beta1.PriorityWarning)); err != nil {
+		t.Fatal(err)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("got %d attempts, want 3", n)
+	}
+	if d := statValue(stats.Webhook, statTotal) - total; d != 3 {
+		t.Errorf("total attempts stat increased by %d, want 3", d)
+	}
+	if d := statValue(stats.Webhook, statError) - failed; d != 2 {
+		t.Errorf("failed attempts stat increased by %d, want 2", d)
+	}
+	if got := header.Get("Authorization"); got != "Bearer secret" {
+		t.Errorf("authorization header = %q", got)
+	}
+	var fe v1beta1.FalcoEvent
+	if err := json.Unmarshal(body, &fe); err != nil || fe.Name != "fe-1" {
+		t.Errorf("posted %s, want FalcoEvent fe-1", body)
+	}
+
+	// a recurrence has no FalcoEvent, one is posted for its payload
+	ev := newEvent("fe-1", v1beta1.PriorityWarning)
+	ev.FalcoEvent = nil
+	if err := w.Send(context.Background(), ev); err != nil {
+		t.Fatal(err)
+	}
+	fe = v1beta1.FalcoEvent{}
+	if err := json.Unmarshal(body, &fe); err != nil || fe.Name != "fe-1" || fe.Spec.Rule != ev.Payload.Rule || fe.Annotations[v1beta1.AnnotationRecurrence] != "true" {
+		t.Errorf("posted %s, want the recurrence of FalcoEvent fe-1", body)
+	}
+}
+
+func TestWebhookRejected(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		http.Error(w, "bad request", http.StatusBadRequest)
+	}))
+	defer srv.Close()
+
+	w, err := NewWebhook(types.WebhookOutputConfig{Address: srv.URL}, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Send(context.Background(), newEvent("fe-1", v1beta1.PriorityWarning)); err == nil {
+		t.Error("expected an error")
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("got %d attempts, rejected documents must not be retried", n)
+	}
+}
+
+func TestStatsHandler(t *testing.T) {
+	rec := httptest.NewRecorder()
+	StatsHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/debug/vars", nil))
+	var vars map[string]json.RawMessage
+	if err := json.Unmarshal(rec.Body.Bytes(), &vars); err != nil {
+		t.Fatalf("served %s: %v", rec.Body, err)
+	}
+	if _, ok := vars["outputs.webhook"]; !ok {
+		t.Errorf("served %s, want the webhook stats", rec.Body)
+	}
+	if _, ok := vars["cmdline"]; ok {
+		t.Error("served the command line")
+	}
+}
@@ -0,0 +1,106 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"context"
+	"fmt"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	core "k8s.io/api/core/v1"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// EventReasonFalcoAlert is the reason of the core/v1 Events mirroring FalcoEvents.
+	EventReasonFalcoAlert = "FalcoAlert"
+	// AnnotationFalcoEvent is set on mirrored core/v1 Events to the name of the FalcoEvent.
+	AnnotationFalcoEvent = "falco.appscode.com/falcoevent"
+)
+
+// Recorder mirrors new FalcoEvents as Warning core/v1 Events on the involved Pod, or the Node
+// for host events. Events are rate limited and aggregated by the underlying event recorder.
+type Recorder struct {
+	recorder    record.EventRecorder
+	reader      client.Reader
+	minPriority types.PriorityType
+}
+
+// NewRecorder returns a Recorder for FalcoEvents at or above minPriority. reader is used to look
+// up the UID of the involved Pod, if it is not part of the Falco output fields.
+func NewRecorder(recorder record.EventRecorder, reader client.Reader, minPriority string) (*Recorder, error) {
+	p := types.Priority(minPriority)
+	if p == types.Default {
+		return nil, fmt.Errorf("unknown priority %q", minPriority)
+	}
+	return &Recorder{recorder: recorder, reader: reader, minPriority: p}, nil
+}
+
+// Record emits a core/v1 Event for the FalcoEvent, if its priority is high enough.
+func (r *Recorder) Record(ctx context.Context, fe *v1beta1.FalcoEvent) {
+	if r == nil || types.Priority(string(fe.Spec.Priority)) < r.minPriority {
+		return
+	}
+	ref := r.involvedObject(ctx, fe)
+	if ref == nil {
+		return
+	}
+	r.recorder.AnnotatedEventf(ref,
+		map[string]string{AnnotationFalcoEvent: fe.Name},
+		core.EventTypeWarning,
+		EventReasonFalcoAlert,
+		"%s (%s): %s, see FalcoEvent %s", fe.Spec.Rule, fe.Spec.Priority, fe.Spec.Output, fe.Name)
+}
+
+func (r *Recorder) involvedObject(ctx context.Context, fe *v1beta1.FalcoEvent) *core.ObjectReference {
+	ns, pod := fe.Labels["k8s.ns.name"], fe.Labels["k8s.pod.name"]
+	if ns != "" && pod != "" {
+		ref := &core.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Pod",
+			Namespace:  ns,
+			Name:       pod,
+		}
+		if fe.Spec.Workload != nil && fe.Spec.Workload.PodUID != "" {
+			ref.UID = ktypes.UID(fe.Spec.Workload.PodUID)
+		} else {
+			var p core.Pod
+			if err := r.reader.Get(ctx, client.ObjectKey{Namespace: ns, Name: pod}, &p); err != nil {
+				klog.V(3).InfoS("failed to look up pod of falco event", "falcoevent", fe.Name, "err", err)
+			} else {
+				ref.UID = p.UID
+			}
+		}
+		return ref
+	}
+
+	if node := fe.Labels["k8s.node.name"]; node != "" {
+		// Node events use the node name as uid, the same as the kubelet
+		return &core.ObjectReference{
+			APIVersion: "v1",
+			Kind:       "Node",
+			Name:       node,
+			UID:        ktypes.UID(node),
+		}
+	}
+	return nil
+}
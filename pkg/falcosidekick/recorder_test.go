@@ -0,0 +1,64 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcosidekick
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestRecorder(t *testing.T) {
+	fake := record.NewFakeRecorder(10)
+	r, err := NewRecorder(fake, nil, "warning")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newEvent := func(name string, priority v1beta1.Priority, labels map[string]string) *v1beta1.FalcoEvent {
+		return &v1beta1.FalcoEvent{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+			Spec: v1beta1.FalcoEventSpec{
+				Rule:     "Terminal shell in container",
+				Priority: priority,
+				Workload: &v1beta1.WorkloadInfo{PodUID: "6f1c"},
+			},
+		}
+	}
+	r.Record(context.TODO(), newEvent("fe-1", v1beta1.PriorityNotice, map[string]string{"k8s.ns.name": "default", "k8s.pod.name": "nginx"}))
+	r.Record(context.TODO(), newEvent("fe-2", v1beta1.PriorityCritical, map[string]string{"k8s.ns.name": "default", "k8s.pod.name": "nginx"}))
+	r.Record(context.TODO(), newEvent("fe-3", v1beta1.PriorityWarning, map[string]string{"k8s.node.name": "node-1"}))
+	close(fake.Events)
+
+	var got []string
+	for ev := range fake.Events {
+		got = append(got, ev)
+	}
+	if len(got) != 2 {
+		t.Fatalf("recorded %d events, want 2: %v", len(got), got)
+	}
+	for i, name := range []string{"fe-2", "fe-3"} {
+		if !strings.HasPrefix(got[i], "Warning "+EventReasonFalcoAlert) || !strings.Contains(got[i], "see FalcoEvent "+name) {
+			t.Errorf("event %d = %q, want a warning for %s", i, got[i], name)
+		}
+	}
+}
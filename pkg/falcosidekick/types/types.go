@@ -264,6 +264,10 @@ type AlertmanagerOutputConfig struct {
 	ExpiresAfter     int
 	ExtraLabels      map[string]string
 	ExtraAnnotations map[string]string
+	// CustomSeverityMap overrides the severity label of the priorities
+	CustomSeverityMap map[PriorityType]string
+	// OutputFieldLabels are the output fields added as labels of the alerts
+	OutputFieldLabels []string
 }
 
 type ElasticsearchOutputConfig struct {
@@ -277,6 +281,12 @@ type ElasticsearchOutputConfig struct {
 	CheckCert       bool
 	MutualTLS       bool
 	CustomHeaders   map[string]string
+	// CreateIndexTemplate creates the index template mapping the fields of the indices
+	CreateIndexTemplate bool
+	// BatchSize is the maximum bytes of the documents of a bulk request
+	BatchSize int
+	// BatchWaitSeconds is the maximum time an event waits to be indexed
+	BatchWaitSeconds int
 }
 
 type influxdbOutputConfig struct {
@@ -305,11 +315,17 @@ type LokiOutputConfig struct {
 	ExtraLabels     string
 	ExtraLabelsList []string
 	CustomHeaders   map[string]string
+	// BatchSize is the maximum bytes of the log lines of a push
+	BatchSize int
+	// BatchWaitSeconds is the maximum time an event waits to be pushed
+	BatchWaitSeconds int
 }
 
 type prometheusOutputConfig struct {
 	ExtraLabels     string
 	ExtraLabelsList []string
+	// MaxLabelValues caps the distinct values of each extra label, zero means no cap
+	MaxLabelValues int
 }
 
 type natsOutputConfig struct {
@@ -423,6 +439,8 @@ type WebhookOutputConfig struct {
 	MinimumPriority string
 	CheckCert       bool
 	MutualTLS       bool
+	// Format is the document posted, the Falco payload or the FalcoEvent
+	Format string
 }
 
 // NodeRedOutputConfig represents parameters for Node-RED
@@ -0,0 +1,104 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcotest
+
+import (
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Option sets an optional part of the FalcoEvents returned by NewEvent.
+type Option func(fe *api.FalcoEvent)
+
+// NewEvent returns a FalcoEvent of the rule reported at t, without output fields.
+func NewEvent(name, rule string, priority api.Priority, t time.Time, opts ...Option) *api.FalcoEvent {
+	fe := &api.FalcoEvent{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}},
+		Spec: api.FalcoEventSpec{
+			Output:       rule + " in " + name,
+			Priority:     priority,
+			Rule:         rule,
+			Time:         metav1.NewMicroTime(t),
+			OutputFields: apiextensionsv1.JSON{Raw: []byte(`{}`)},
+			Source:       "syscall",
+		},
+	}
+	for _, opt := range opts {
+		opt(fe)
+	}
+	return fe
+}
+
+// InNamespace labels the event with the namespace of its workload. An empty namespace is a host event.
+func InNamespace(ns string) Option {
+	return func(fe *api.FalcoEvent) {
+		if ns != "" {
+			fe.Labels[api.LabelNamespaceName] = ns
+		}
+	}
+}
+
+// OnNode labels the event with the node it was reported on and sets its hostname. An empty node
+// leaves the event unlabeled.
+func OnNode(node string) Option {
+	return func(fe *api.FalcoEvent) {
+		if node != "" {
+			fe.Labels[api.LabelNodeName] = node
+			fe.Spec.Hostname = node
+		}
+	}
+}
+
+// OnPod labels the event with the namespace and pod of its workload and sets its workload section.
+// An empty namespace is a host event.
+func OnPod(ns, pod string) Option {
+	return func(fe *api.FalcoEvent) {
+		if ns != "" {
+			fe.Labels[api.LabelNamespaceName] = ns
+			fe.Labels[api.LabelPodName] = pod
+			fe.Spec.Workload = &api.WorkloadInfo{Namespace: ns, Pod: pod, PodUID: "uid-" + pod}
+		}
+	}
+}
+
+// WithOutputFields sets the output fields of the event to the JSON object.
+func WithOutputFields(fields string) Option {
+	return func(fe *api.FalcoEvent) {
+		fe.Spec.OutputFields.Raw = []byte(fields)
+	}
+}
+
+// WithCount sets the number of occurrences of the event.
+func WithCount(count int64) Option {
+	return func(fe *api.FalcoEvent) {
+		fe.Status.Count = count
+	}
+}
+
+// V1beta1 returns the event in the v1beta1 version, for the tests of the clients of the api server.
+func V1beta1(fe *api.FalcoEvent) v1beta1.FalcoEvent {
+	var out v1beta1.FalcoEvent
+	if err := v1beta1.Convert_falco_FalcoEvent_To_v1beta1_FalcoEvent(fe, &out, nil); err != nil {
+		panic(err)
+	}
+	return out
+}
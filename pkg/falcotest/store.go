@@ -0,0 +1,71 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcotest
+
+import (
+	"context"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+// Store is a FalcoEvent storage for tests. It lists a fixed set of events, deletions are only recorded.
+type Store struct {
+	rest.TableConvertor
+
+	// Events are listed at ResourceVersion.
+	Events          []api.FalcoEvent
+	ResourceVersion string
+	// Watcher is returned by Watch, an empty watch if it is nil.
+	Watcher watch.Interface
+	// WatchResourceVersion is the resource version the last watch started at.
+	WatchResourceVersion string
+	// Deleted are the names of the deleted events, in order.
+	Deleted []string
+}
+
+var (
+	_ rest.Lister          = &Store{}
+	_ rest.Watcher         = &Store{}
+	_ rest.GracefulDeleter = &Store{}
+)
+
+func (s *Store) NewList() runtime.Object {
+	return &api.FalcoEventList{}
+}
+
+func (s *Store) List(_ context.Context, _ *metainternalversion.ListOptions) (runtime.Object, error) {
+	return &api.FalcoEventList{ListMeta: metav1.ListMeta{ResourceVersion: s.ResourceVersion}, Items: s.Events}, nil
+}
+
+func (s *Store) Watch(_ context.Context, options *metainternalversion.ListOptions) (watch.Interface, error) {
+	s.WatchResourceVersion = options.ResourceVersion
+	if s.Watcher == nil {
+		return watch.NewEmptyWatch(), nil
+	}
+	return s.Watcher, nil
+}
+
+func (s *Store) Delete(_ context.Context, name string, _ rest.ValidateObjectFunc, _ *metav1.DeleteOptions) (runtime.Object, bool, error) {
+	s.Deleted = append(s.Deleted, name)
+	return nil, true, nil
+}
@@ -0,0 +1,115 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var dimensions = map[string]bool{
+	ByRule:      true,
+	ByPriority:  true,
+	ByNamespace: true,
+	ByNode:      true,
+	ByWorkload:  true,
+	ByBucket:    true,
+}
+
+// ParseQuery reads a query from the by, since and until parameters, eg,
+// ?by=namespace,rule&since=2024-05-01T00:00:00Z. Times are RFC3339.
+func ParseQuery(q url.Values) (Query, error) {
+	var out Query
+	if s := q.Get("by"); s != "" {
+		for _, d := range strings.Split(s, ",") {
+			d = strings.TrimSpace(d)
+			if !dimensions[d] {
+				return out, fmt.Errorf("unknown dimension %q", d)
+			}
+			out.By = append(out.By, d)
+		}
+	}
+	var err error
+	if s := q.Get("since"); s != "" {
+		if out.Since, err = time.Parse(time.RFC3339, s); err != nil {
+			return out, fmt.Errorf("invalid since: %v", err)
+		}
+	}
+	if s := q.Get("until"); s != "" {
+		if out.Until, err = time.Parse(time.RFC3339, s); err != nil {
+			return out, fmt.Errorf("invalid until: %v", err)
+		}
+	}
+	return out, nil
+}
+
+type summaryRow struct {
+	Rule        string     `json:"rule,omitempty"`
+	Priority    string     `json:"priority,omitempty"`
+	Namespace   string     `json:"namespace,omitempty"`
+	Node        string     `json:"node,omitempty"`
+	Workload    string     `json:"workload,omitempty"`
+	Bucket      *time.Time `json:"bucket,omitempty"`
+	Events      int        `json:"events"`
+	Occurrences int64      `json:"occurrences"`
+}
+
+// Handler serves the rows of the query given as parameters as JSON. It fails with 503 until the
+// index is synced.
+func (x *Index) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			http.Error(w, "Please send with get http method", http.StatusMethodNotAllowed)
+			return
+		}
+		q, err := ParseQuery(r.URL.Query())
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if !x.Synced() {
+			http.Error(w, "FalcoEvent index is not synced yet", http.StatusServiceUnavailable)
+			return
+		}
+
+		rows := x.Query(q)
+		out := make([]summaryRow, 0, len(rows))
+		for _, row := range rows {
+			sr := summaryRow{
+				Rule:        row.Rule,
+				Priority:    row.Priority,
+				Namespace:   row.Namespace,
+				Node:        row.Node,
+				Workload:    row.Workload,
+				Events:      row.Events,
+				Occurrences: row.Occurrences,
+			}
+			if !row.Bucket.IsZero() {
+				sr.Bucket = &row.Bucket
+			}
+			out = append(out, sr)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(out); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
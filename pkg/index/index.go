@@ -0,0 +1,447 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+const (
+	// DefaultBucket is the width of the time buckets events are counted in.
+	DefaultBucket = time.Hour
+
+	pageSize = 500
+)
+
+// Store is the FalcoEvent storage the index is fed from.
+type Store interface {
+	rest.Lister
+	rest.Watcher
+}
+
+// Group identifies the FalcoEvents counted together. Host events have an empty namespace and workload.
+type Group struct {
+	Rule      string
+	Priority  string
+	Namespace string
+	Node      string
+	Workload  string
+	// Bucket is the start of the time bucket of the events.
+	Bucket time.Time
+}
+
+// Counts are the number of FalcoEvents of a group and the sum of their occurrences.
+type Counts struct {
+	Events      int
+	Occurrences int64
+}
+
+type eventInfo struct {
+	group       Group
+	occurrences int64
+	uuid        string
+	time        time.Time
+}
+
+// Event references a stored FalcoEvent.
+type Event struct {
+	Name  string
+	UUID  string
+	Time  time.Time
+	Group Group
+}
+
+// Index counts the stored FalcoEvents by group. It is fed by a watch on the FalcoEvent storage,
+// so the counts are maintained as events are written and deleted and queries cost O(groups).
+type Index struct {
+	store  Store
+	bucket time.Duration
+
+	mu     sync.RWMutex
+	events map[string]eventInfo
+	groups map[Group]*Counts
+	// tags are the tags of the last event seen of each rule
+	tags   map[string][]string
+	synced bool
+}
+
+func New(store Store, bucket time.Duration) *Index {
+	if bucket <= 0 {
+		bucket = DefaultBucket
+	}
+	return &Index{
+		store:  store,
+		bucket: bucket,
+		events: map[string]eventInfo{},
+		groups: map[Group]*Counts{},
+		tags:   map[string][]string{},
+	}
+}
+
+// NeedLeaderElection returns false, as every replica serves the index of its own view of the storage.
+func (x *Index) NeedLeaderElection() bool {
+	return false
+}
+
+// Start lists the FalcoEvents and watches them until the context is done. It implements manager.Runnable.
+func (x *Index) Start(ctx context.Context) error {
+	klog.Infoln("Starts the FalcoEvent index")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := x.sync(ctx); err != nil {
+			klog.ErrorS(err, "failed to sync the FalcoEvent index")
+		}
+	}, 5*time.Second)
+	return nil
+}
+
+// sync rebuilds the index from a list and applies the changes after it until the watch ends.
+func (x *Index) sync(ctx context.Context) error {
+	ctx = genericapirequest.WithNamespace(ctx, metav1.NamespaceNone)
+	x.setSynced(false)
+
+	events := map[string]eventInfo{}
+	tags := map[string][]string{}
+	opts := &metainternalversion.ListOptions{Limit: pageSize}
+	var rv string
+	for {
+		obj, err := x.store.List(ctx, opts)
+		if err != nil {
+			return err
+		}
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if fe, ok := item.(*api.FalcoEvent); ok {
+				events[fe.Name] = x.infoOf(fe)
+				tags[fe.Spec.Rule] = fe.Spec.Tags
+			}
+		}
+		lm, err := meta.ListAccessor(obj)
+		if err != nil {
+			return err
+		}
+		if rv == "" {
+			// the continued pages are served from the snapshot of the first one
+			rv = lm.GetResourceVersion()
+		}
+		if lm.GetContinue() == "" {
+			break
+		}
+		opts.Continue = lm.GetContinue()
+	}
+	x.reset(events, tags)
+
+	w, err := x.store.Watch(ctx, &metainternalversion.ListOptions{ResourceVersion: rv})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	x.setSynced(true)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				if fe, ok := ev.Object.(*api.FalcoEvent); ok {
+					x.observe(fe)
+					syncLag.Observe(time.Since(lastWrite(fe)).Seconds())
+				}
+			case watch.Deleted:
+				if fe, ok := ev.Object.(*api.FalcoEvent); ok {
+					x.forget(fe.Name)
+				}
+			case watch.Error:
+				return apierrors.FromObject(ev.Object)
+			}
+		}
+	}
+}
+
+// lastWrite returns the time the event was last written by any manager.
+func lastWrite(fe *api.FalcoEvent) time.Time {
+	t := fe.CreationTimestamp.Time
+	for _, mf := range fe.ManagedFields {
+		if mf.Time != nil && mf.Time.After(t) {
+			t = mf.Time.Time
+		}
+	}
+	return t
+}
+
+func (x *Index) infoOf(fe *api.FalcoEvent) eventInfo {
+	return eventInfo{
+		group: Group{
+			Rule:      fe.Spec.Rule,
+			Priority:  string(fe.Spec.Priority),
+			Namespace: fe.Labels[api.LabelNamespaceName],
+			Node:      fe.Labels[api.LabelNodeName],
+			Workload:  WorkloadName(fe.Labels[api.LabelPodName]),
+			Bucket:    fe.Spec.Time.UTC().Truncate(x.bucket),
+		},
+		occurrences: fe.Status.Count,
+		uuid:        fe.Spec.UUID,
+		time:        fe.Spec.Time.Time,
+	}
+}
+
+func (x *Index) setSynced(synced bool) {
+	x.mu.Lock()
+	x.synced = synced
+	x.mu.Unlock()
+	if synced {
+		indexSynced.Set(1)
+	} else {
+		indexSynced.Set(0)
+	}
+}
+
+func (x *Index) reset(events map[string]eventInfo, tags map[string][]string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.events = events
+	x.tags = tags
+	x.groups = map[Group]*Counts{}
+	for _, info := range events {
+		x.addLocked(info)
+	}
+	indexGroups.Set(float64(len(x.groups)))
+}
+
+func (x *Index) observe(fe *api.FalcoEvent) {
+	info := x.infoOf(fe)
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if old, ok := x.events[fe.Name]; ok {
+		x.removeLocked(old)
+	}
+	x.events[fe.Name] = info
+	x.tags[fe.Spec.Rule] = fe.Spec.Tags
+	x.addLocked(info)
+	indexGroups.Set(float64(len(x.groups)))
+}
+
+func (x *Index) forget(name string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	if old, ok := x.events[name]; ok {
+		x.removeLocked(old)
+		delete(x.events, name)
+	}
+	indexGroups.Set(float64(len(x.groups)))
+}
+
+func (x *Index) addLocked(info eventInfo) {
+	c, ok := x.groups[info.group]
+	if !ok {
+		c = &Counts{}
+		x.groups[info.group] = c
+	}
+	c.Events++
+	c.Occurrences += info.occurrences
+}
+
+func (x *Index) removeLocked(info eventInfo) {
+	c, ok := x.groups[info.group]
+	if !ok {
+		return
+	}
+	c.Events--
+	c.Occurrences -= info.occurrences
+	if c.Events <= 0 {
+		delete(x.groups, info.group)
+	}
+}
+
+// Bucket returns the width of the time buckets events are counted in.
+func (x *Index) Bucket() time.Duration {
+	return x.bucket
+}
+
+// Synced returns true once the index reflects the storage.
+func (x *Index) Synced() bool {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	return x.synced
+}
+
+// Tags returns the tags of the rules, as carried by their last seen event.
+func (x *Index) Tags() map[string][]string {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	out := make(map[string][]string, len(x.tags))
+	for rule, tags := range x.tags {
+		out[rule] = tags
+	}
+	return out
+}
+
+// Latest returns the n most recent events of each rule with a time in [since, until), the most
+// recent first. Zero times are unbounded. It costs O(events).
+func (x *Index) Latest(since, until time.Time, n int) map[string][]Event {
+	out := map[string][]Event{}
+	if n <= 0 {
+		return out
+	}
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	for name, info := range x.events {
+		if !since.IsZero() && info.time.Before(since) || !until.IsZero() && !info.time.Before(until) {
+			continue
+		}
+		events := out[info.group.Rule]
+		i := sort.Search(len(events), func(i int) bool {
+			return events[i].Time.Before(info.time) || events[i].Time.Equal(info.time) && events[i].Name > name
+		})
+		if i >= n {
+			continue
+		}
+		events = slices.Insert(events, i, Event{Name: name, UUID: info.uuid, Time: info.time, Group: info.group})
+		if len(events) > n {
+			events = events[:n]
+		}
+		out[info.group.Rule] = events
+	}
+	return out
+}
+
+// Dimensions a Query can group by.
+const (
+	ByRule      = "rule"
+	ByPriority  = "priority"
+	ByNamespace = "namespace"
+	ByNode      = "node"
+	ByWorkload  = "workload"
+	ByBucket    = "bucket"
+)
+
+// Query selects the groups with a bucket in [Since, Until) and sums them by the By dimensions.
+// Zero times are unbounded.
+type Query struct {
+	By    []string
+	Since time.Time
+	Until time.Time
+}
+
+// Row is the sum of the groups with the same values of the queried dimensions. The other
+// dimensions are empty.
+type Row struct {
+	Group
+	Counts
+}
+
+// Query returns the rows of the query, the largest event counts first.
+func (x *Index) Query(q Query) []Row {
+	by := map[string]bool{}
+	for _, d := range q.By {
+		by[d] = true
+	}
+
+	x.mu.RLock()
+	sums := make(map[Group]*Counts)
+	for g, c := range x.groups {
+		if !q.Since.IsZero() && g.Bucket.Add(x.bucket).Before(q.Since) ||
+			!q.Until.IsZero() && !g.Bucket.Before(q.Until) {
+			continue
+		}
+		k := project(g, by)
+		s, ok := sums[k]
+		if !ok {
+			s = &Counts{}
+			sums[k] = s
+		}
+		s.Events += c.Events
+		s.Occurrences += c.Occurrences
+	}
+	x.mu.RUnlock()
+
+	rows := make([]Row, 0, len(sums))
+	for g, c := range sums {
+		rows = append(rows, Row{Group: g, Counts: *c})
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Events != b.Events {
+			return a.Events > b.Events
+		}
+		return a.Group.less(b.Group)
+	})
+	return rows
+}
+
+func project(g Group, by map[string]bool) Group {
+	var out Group
+	if by[ByRule] {
+		out.Rule = g.Rule
+	}
+	if by[ByPriority] {
+		out.Priority = g.Priority
+	}
+	if by[ByNamespace] {
+		out.Namespace = g.Namespace
+	}
+	if by[ByNode] {
+		out.Node = g.Node
+	}
+	if by[ByWorkload] {
+		out.Workload = g.Workload
+	}
+	if by[ByBucket] {
+		out.Bucket = g.Bucket
+	}
+	return out
+}
+
+func (g Group) less(o Group) bool {
+	switch {
+	case g.Rule != o.Rule:
+		return g.Rule < o.Rule
+	case g.Priority != o.Priority:
+		return g.Priority < o.Priority
+	case g.Namespace != o.Namespace:
+		return g.Namespace < o.Namespace
+	case g.Node != o.Node:
+		return g.Node < o.Node
+	case g.Workload != o.Workload:
+		return g.Workload < o.Workload
+	default:
+		return g.Bucket.Before(o.Bucket)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/pkg/falcotest"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+)
+
+func TestIndex(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 10, 30, 0, 0, time.UTC)
+	event := func(name, rule string, t time.Time, opts ...falcotest.Option) *api.FalcoEvent {
+		return falcotest.NewEvent(name, rule, api.PriorityWarning, t, append(opts, falcotest.OnNode("node-1"), falcotest.WithCount(2))...)
+	}
+	watcher := watch.NewFake()
+	store := &falcotest.Store{
+		Events: []api.FalcoEvent{
+			*event("fe-1", "Terminal shell", t0, falcotest.OnPod("shop", "cart-7d4b9c8f6d-x2x9z")),
+			*event("fe-2", "Terminal shell", t0.Add(time.Hour), falcotest.OnPod("shop", "cart-7d4b9c8f6d-q8w4k")),
+		},
+		ResourceVersion: "7",
+		Watcher:         watcher,
+	}
+	x := New(store, time.Hour)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = x.sync(ctx)
+	}()
+	if err := wait.PollUntilContextTimeout(ctx, time.Millisecond, time.Second, true, func(context.Context) (bool, error) {
+		return x.Synced(), nil
+	}); err != nil {
+		t.Fatal("index did not sync")
+	}
+	if store.WatchResourceVersion != "7" {
+		t.Errorf("watch started at resource version %q, want 7", store.WatchResourceVersion)
+	}
+
+	watcher.Add(event("fe-3", "Read sensitive file", t0))
+	watcher.Modify(event("fe-2", "Terminal shell", t0.Add(time.Hour), falcotest.OnPod("shop", "db-0")))
+	watcher.Delete(event("fe-1", "", t0))
+	watcher.Stop()
+	<-done
+
+	got := x.Query(Query{By: []string{ByWorkload}})
+	want := []Row{
+		{Group: Group{Workload: ""}, Counts: Counts{Events: 1, Occurrences: 2}},
+		{Group: Group{Workload: "db"}, Counts: Counts{Events: 1, Occurrences: 2}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("by workload got %+v, want %+v", got, want)
+	}
+
+	got = x.Query(Query{By: []string{ByRule}, Since: t0.Add(time.Hour)})
+	want = []Row{
+		{Group: Group{Rule: "Terminal shell"}, Counts: Counts{Events: 1, Occurrences: 2}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("since got %+v, want %+v", got, want)
+	}
+
+	latest := x.Latest(t0.Add(time.Hour), time.Time{}, 1)
+	if len(latest) != 1 || len(latest["Terminal shell"]) != 1 || latest["Terminal shell"][0].Name != "fe-2" {
+		t.Errorf("latest got %+v, want fe-2", latest)
+	}
+}
+
+func TestWorkloadName(t *testing.T) {
+	for pod, want := range map[string]string{
+		"cart-7d4b9c8f6d-x2x9z": "cart",
+		"fluent-bit-x2x9z":      "fluent-bit",
+		"db-0":                  "db",
+		"db-10":                 "db",
+		"static-pod":            "static-pod",
+		"etcd-control-plane":    "etcd-control-plane",
+		"":                      "",
+	} {
+		if got := WorkloadName(pod); got != want {
+			t.Errorf("WorkloadName(%q) = %q, want %q", pod, got, want)
+		}
+	}
+}
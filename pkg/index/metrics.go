@@ -0,0 +1,66 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricPrefix = "falco_appscode_com_"
+
+var (
+	indexSynced = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "index_synced",
+		Help: "Whether the FalcoEvent index reflects the storage",
+	})
+
+	indexGroups = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: metricPrefix + "index_groups",
+		Help: "Number of groups counted by the FalcoEvent index",
+	})
+
+	syncLag = prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    metricPrefix + "index_sync_lag_seconds",
+		Help:    "Time from a FalcoEvent write until it is applied to the index",
+		Buckets: prometheus.ExponentialBuckets(0.01, 2, 14),
+	})
+)
+
+func init() {
+	metrics.Registry.MustRegister(indexSynced, indexGroups, syncLag)
+}
+
+var eventsDesc = prometheus.NewDesc(
+	metricPrefix+"events",
+	"Number of stored FalcoEvents",
+	[]string{"rule", "priority", "namespace", "node", "workload"},
+	nil,
+)
+
+// Describe implements prometheus.Collector.
+func (x *Index) Describe(ch chan<- *prometheus.Desc) {
+	ch <- eventsDesc
+}
+
+// Collect implements prometheus.Collector. It reports the stored events summed over the time buckets.
+func (x *Index) Collect(ch chan<- prometheus.Metric) {
+	for _, row := range x.Query(Query{By: []string{ByRule, ByPriority, ByNamespace, ByNode, ByWorkload}}) {
+		ch <- prometheus.MustNewConstMetric(eventsDesc, prometheus.GaugeValue, float64(row.Events),
+			row.Rule, row.Priority, row.Namespace, row.Node, row.Workload)
+	}
+}
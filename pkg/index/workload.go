@@ -0,0 +1,71 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package index
+
+import (
+	"strings"
+)
+
+// generatedAlphabet is the alphabet of the suffixes generated by the workload controllers.
+const generatedAlphabet = "bcdfghjklmnpqrstvwxz2456789"
+
+// WorkloadName returns the name of the workload of a pod, ie, the pod name without the
+// suffixes added by the Deployment, ReplicaSet, DaemonSet, Job and StatefulSet controllers.
+// Pods without a recognized suffix are their own workload.
+func WorkloadName(pod string) string {
+	parts := strings.Split(pod, "-")
+	n := len(parts)
+	if n < 2 {
+		return pod
+	}
+	last := parts[n-1]
+	switch {
+	case isGenerated(last, 5, 5):
+		// <deployment>-<pod-template-hash>-<suffix>
+		if n >= 3 && isGenerated(parts[n-2], 6, 10) {
+			return strings.Join(parts[:n-2], "-")
+		}
+		return strings.Join(parts[:n-1], "-")
+	case isOrdinal(last):
+		return strings.Join(parts[:n-1], "-")
+	}
+	return pod
+}
+
+func isGenerated(s string, minLen, maxLen int) bool {
+	if len(s) < minLen || len(s) > maxLen {
+		return false
+	}
+	for _, r := range s {
+		if !strings.ContainsRune(generatedAlphabet, r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isOrdinal(s string) bool {
+	if s == "" || len(s) > 1 && s[0] == '0' {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
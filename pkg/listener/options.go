@@ -0,0 +1,88 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package listener
+
+import (
+	"fmt"
+
+	"github.com/spf13/pflag"
+)
+
+// Options configures a dedicated HTTP listener. It serves TLS if a certificate is set.
+type Options struct {
+	// Name prefixes the flags, eg, --metrics-bind-address.
+	Name string
+
+	// BindAddress is the address to listen on. Empty or "0" disables the listener.
+	BindAddress string
+	CertFile    string
+	KeyFile     string
+	// ClientCAFile verifies client certificates. Unless Authenticate is set, every client
+	// has to present a certificate signed by it.
+	ClientCAFile string
+	// Authenticate requires a client certificate or a bearer token reviewed by the kube-apiserver.
+	Authenticate bool
+	// Authorize checks every request with a SubjectAccessReview for its path and method.
+	Authorize bool
+}
+
+func NewOptions(name, bindAddress string) *Options {
+	return &Options{
+		Name:        name,
+		BindAddress: bindAddress,
+	}
+}
+
+func (o *Options) flag(name string) string {
+	return o.Name + "-" + name
+}
+
+func (o *Options) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.BindAddress, o.flag("bind-address"), o.BindAddress, fmt.Sprintf("Address the %s listener binds to, eg, :8443. Empty or 0 disables it.", o.Name))
+	fs.StringVar(&o.CertFile, o.flag("tls-cert-file"), o.CertFile, fmt.Sprintf("TLS certificate of the %s listener. If empty, the listener serves plain HTTP.", o.Name))
+	fs.StringVar(&o.KeyFile, o.flag("tls-private-key-file"), o.KeyFile, fmt.Sprintf("TLS private key of the %s listener", o.Name))
+	fs.StringVar(&o.ClientCAFile, o.flag("client-ca-file"), o.ClientCAFile, fmt.Sprintf("CA bundle verifying the client certificates of the %s listener. Unless --%s is set, clients must present a certificate.", o.Name, o.flag("authentication")))
+	fs.BoolVar(&o.Authenticate, o.flag("authentication"), o.Authenticate, fmt.Sprintf("If true, requests to the %s listener must authenticate with a client certificate or a bearer token reviewed by the kube-apiserver", o.Name))
+	fs.BoolVar(&o.Authorize, o.flag("authorization"), o.Authorize, fmt.Sprintf("If true, requests to the %s listener are authorized by the kube-apiserver with a SubjectAccessReview of the request path and method", o.Name))
+}
+
+// Enabled returns true if the listener is to be started.
+func (o *Options) Enabled() bool {
+	return o.BindAddress != "" && o.BindAddress != "0"
+}
+
+// TLS returns true if the listener serves TLS.
+func (o *Options) TLS() bool {
+	return o.CertFile != ""
+}
+
+func (o *Options) Validate() []error {
+	var errs []error
+	if (o.CertFile == "") != (o.KeyFile == "") {
+		errs = append(errs, fmt.Errorf("--%s and --%s must be set together", o.flag("tls-cert-file"), o.flag("tls-private-key-file")))
+	}
+	if o.ClientCAFile != "" && !o.TLS() {
+		errs = append(errs, fmt.Errorf("--%s requires --%s", o.flag("client-ca-file"), o.flag("tls-cert-file")))
+	}
+	if o.Authenticate && !o.TLS() {
+		errs = append(errs, fmt.Errorf("--%s requires --%s, bearer tokens are not accepted over plain HTTP", o.flag("authentication"), o.flag("tls-cert-file")))
+	}
+	if o.Authorize && !o.Authenticate {
+		errs = append(errs, fmt.Errorf("--%s requires --%s", o.flag("authorization"), o.flag("authentication")))
+	}
+	return errs
+}
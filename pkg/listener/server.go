@@ -0,0 +1,254 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package listener
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"k8s.io/apiserver/pkg/authentication/authenticator"
+	"k8s.io/apiserver/pkg/authentication/authenticatorfactory"
+	"k8s.io/apiserver/pkg/authorization/authorizer"
+	"k8s.io/apiserver/pkg/authorization/authorizerfactory"
+	"k8s.io/apiserver/pkg/server/dynamiccertificates"
+	genericoptions "k8s.io/apiserver/pkg/server/options"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	healthzPath = "/healthz"
+	readyzPath  = "/readyz"
+
+	shutdownTimeout = 30 * time.Second
+)
+
+// Server serves a set of handlers on a dedicated listener, along with /healthz and /readyz.
+// The health endpoints are never authenticated, so the kubelet can probe them. It runs on
+// every replica.
+type Server struct {
+	opts  Options
+	mux   *http.ServeMux
+	authn authenticator.Request
+	authz authorizer.Authorizer
+}
+
+var _ manager.LeaderElectionRunnable = &Server{}
+
+// New returns a server of the handlers keyed by path. ready are the readiness checks.
+// kc is used to review tokens and access, it may be nil unless authentication is enabled.
+func New(opts Options, kc kubernetes.Interface, handlers map[string]http.Handler, ready map[string]healthz.Checker) (*Server, error) {
+	s := &Server{opts: opts, mux: http.NewServeMux()}
+
+	if opts.Authenticate {
+		cfg := authenticatorfactory.DelegatingAuthenticatorConfig{
+			TokenAccessReviewClient: kc.AuthenticationV1(),
+			WebhookRetryBackoff:     genericoptions.DefaultAuthWebhookRetryBackoff(),
+			CacheTTL:                2 * time.Minute,
+		}
+		if opts.ClientCAFile != "" {
+			ca, err := dynamiccertificates.NewDynamicCAContentFromFile(opts.Name+"-client-ca", opts.ClientCAFile)
+			if err != nil {
+				return nil, err
+			}
+			cfg.ClientCertificateCAContentProvider = ca
+		}
+		authn, _, err := cfg.New()
+		if err != nil {
+			return nil, err
+		}
+		s.authn = authn
+	}
+	if opts.Authorize {
+		authz, err := authorizerfactory.DelegatingAuthorizerConfig{
+			SubjectAccessReviewClient: kc.AuthorizationV1(),
+			AllowCacheTTL:             5 * time.Minute,
+			DenyCacheTTL:              30 * time.Second,
+			WebhookRetryBackoff:       genericoptions.DefaultAuthWebhookRetryBackoff(),
+		}.New()
+		if err != nil {
+			return nil, err
+		}
+		s.authz = authz
+	}
+
+	for path, h := range handlers {
+		if _, ok := h.(ResourceHandler); ok && !opts.Authorize {
+			return nil, fmt.Errorf("%s serves API objects and requires --%s", path, opts.flag("authorization"))
+		}
+		s.mux.Handle(path, s.protect(h))
+	}
+	live := &healthz.Handler{Checks: map[string]healthz.Checker{"ping": healthz.Ping}}
+	s.mux.Handle(healthzPath, http.StripPrefix(healthzPath, live))
+	s.mux.Handle(healthzPath+"/", http.StripPrefix(healthzPath, live))
+	if ready == nil {
+		ready = map[string]healthz.Checker{"ping": healthz.Ping}
+	}
+	readyz := &healthz.Handler{Checks: ready}
+	s.mux.Handle(readyzPath, http.StripPrefix(readyzPath, readyz))
+	s.mux.Handle(readyzPath+"/", http.StripPrefix(readyzPath, readyz))
+	return s, nil
+}
+
+// ResourceHandler serves the objects of an API resource outside of the API. Its requests are
+// authorized for the verb on the resource as well as for their path, so the RBAC of the resource
+// applies. It is only served by a listener with authorization.
+type ResourceHandler struct {
+	http.Handler
+	Group    string
+	Resource string
+	Verb     string
+}
+
+// protect wraps the handler with the authentication and authorization of the listener.
+func (s *Server) protect(h http.Handler) http.Handler {
+	if s.authn == nil {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		resp, ok, err := s.authn.AuthenticateRequest(r)
+		if err != nil || !ok {
+			if err != nil {
+				klog.V(4).InfoS("failed to authenticate request", "listener", s.opts.Name, "path", r.URL.Path, "err", err)
+			}
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if s.authz != nil {
+			attrs := authorizer.AttributesRecord{
+				User:            resp.User,
+				Verb:            strings.ToLower(r.Method),
+				Path:            r.URL.Path,
+				ResourceRequest: false,
+			}
+			if !s.authorize(w, r, attrs, fmt.Sprintf("path %q", attrs.Path)) {
+				return
+			}
+			if rh, ok := h.(ResourceHandler); ok {
+				attrs = authorizer.AttributesRecord{
+					User:            resp.User,
+					Verb:            rh.Verb,
+					APIGroup:        rh.Group,
+					Resource:        rh.Resource,
+					ResourceRequest: true,
+				}
+				if !s.authorize(w, r, attrs, fmt.Sprintf("resource %q in API group %q", rh.Resource, rh.Group)) {
+					return
+				}
+			}
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// authorize returns true if the request is allowed, else it writes the error response.
+func (s *Server) authorize(w http.ResponseWriter, r *http.Request, attrs authorizer.AttributesRecord, target string) bool {
+	decision, reason, err := s.authz.Authorize(r.Context(), attrs)
+	if err != nil {
+		klog.ErrorS(err, "failed to authorize request", "listener", s.opts.Name, "user", attrs.User.GetName(), "path", r.URL.Path)
+		http.Error(w, "Authorization failed", http.StatusInternalServerError)
+		return false
+	}
+	if decision != authorizer.DecisionAllow {
+		http.Error(w, fmt.Sprintf("Forbidden: user %q cannot %s %s: %s", attrs.User.GetName(), attrs.Verb, target, reason), http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+// Start serves until the context is done. It implements manager.Runnable.
+func (s *Server) Start(ctx context.Context) error {
+	srv := &http.Server{
+		Handler:           s.mux,
+		ReadHeaderTimeout: 30 * time.Second,
+		BaseContext:       func(net.Listener) context.Context { return ctx },
+	}
+
+	if s.opts.TLS() {
+		cw, err := certwatcher.New(s.opts.CertFile, s.opts.KeyFile)
+		if err != nil {
+			return err
+		}
+		go func() {
+			if err := cw.Start(ctx); err != nil {
+				klog.ErrorS(err, "failed to watch the certificate", "listener", s.opts.Name)
+			}
+		}()
+		srv.TLSConfig = &tls.Config{
+			MinVersion:     tls.VersionTLS12,
+			GetCertificate: cw.GetCertificate,
+		}
+		if s.opts.ClientCAFile != "" {
+			pem, err := os.ReadFile(s.opts.ClientCAFile)
+			if err != nil {
+				return err
+			}
+			pool := x509.NewCertPool()
+			if !pool.AppendCertsFromPEM(pem) {
+				return fmt.Errorf("no certificate found in %s", s.opts.ClientCAFile)
+			}
+			srv.TLSConfig.ClientCAs = pool
+			srv.TLSConfig.ClientAuth = tls.RequireAndVerifyClientCert
+			if s.opts.Authenticate {
+				// bearer tokens are accepted as well
+				srv.TLSConfig.ClientAuth = tls.VerifyClientCertIfGiven
+			}
+		}
+	}
+
+	l, err := net.Listen("tcp", s.opts.BindAddress)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s for the %s listener: %w", s.opts.BindAddress, s.opts.Name, err)
+	}
+	klog.InfoS("Serving", "listener", s.opts.Name, "address", l.Addr().String(), "tls", s.opts.TLS(), "authentication", s.opts.Authenticate, "authorization", s.opts.Authorize)
+
+	errCh := make(chan error, 1)
+	go func() {
+		if s.opts.TLS() {
+			errCh <- srv.ServeTLS(l, "", "")
+		} else {
+			errCh <- srv.Serve(l)
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		return srv.Shutdown(shutdownCtx)
+	}
+}
@@ -0,0 +1,131 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package listener
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// fakeKubeAPIServer reviews the tokens "valid" and "auditor". It allows GET /metrics and
+// /falcoexport, and the auditor to list falcoevents.
+func fakeKubeAPIServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var out any
+		switch r.URL.Path {
+		case "/apis/authentication.k8s.io/v1/tokenreviews":
+			var review authenticationv1.TokenReview
+			if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+				t.Error(err)
+			}
+			switch review.Spec.Token {
+			case "valid":
+				review.Status.Authenticated = true
+				review.Status.User.Username = "system:serviceaccount:monitoring:prometheus"
+			case "auditor":
+				review.Status.Authenticated = true
+				review.Status.User.Username = "auditor"
+			}
+			out = review
+		case "/apis/authorization.k8s.io/v1/subjectaccessreviews":
+			var review authorizationv1.SubjectAccessReview
+			if err := json.NewDecoder(r.Body).Decode(&review); err != nil {
+				t.Error(err)
+			}
+			if attrs := review.Spec.NonResourceAttributes; attrs != nil {
+				review.Status.Allowed = (attrs.Path == "/metrics" || attrs.Path == "/falcoexport") && attrs.Verb == "get"
+			}
+			if attrs := review.Spec.ResourceAttributes; attrs != nil {
+				review.Status.Allowed = review.Spec.User == "auditor" && attrs.Resource == "falcoevents" && attrs.Verb == "list"
+			}
+			out = review
+		default:
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(out)
+	}))
+}
+
+func TestServerAuth(t *testing.T) {
+	apiserver := fakeKubeAPIServer(t)
+	defer apiserver.Close()
+	kc := kubernetes.NewForConfigOrDie(&rest.Config{Host: apiserver.URL})
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	handlers := map[string]http.Handler{
+		"/metrics":     ok,
+		"/falcoexport": ResourceHandler{Handler: ok, Group: "falco.appscode.com", Resource: "falcoevents", Verb: "list"},
+	}
+	if _, err := New(Options{Name: "metrics", Authenticate: true}, kc, handlers, nil); err == nil {
+		t.Error("resource handler is served without authorization")
+	}
+	s, err := New(Options{Name: "metrics", Authenticate: true, Authorize: true}, kc, handlers, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		path  string
+		token string
+		want  int
+	}{
+		{path: "/healthz", want: http.StatusOK},
+		{path: "/readyz", want: http.StatusOK},
+		{path: "/metrics", want: http.StatusUnauthorized},
+		{path: "/metrics", token: "invalid", want: http.StatusUnauthorized},
+		{path: "/metrics", token: "valid", want: http.StatusOK},
+		{path: "/falcoexport", token: "valid", want: http.StatusForbidden},
+		{path: "/falcoexport", token: "auditor", want: http.StatusOK},
+	} {
+		req := httptest.NewRequest(http.MethodGet, tc.path, nil)
+		if tc.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tc.token)
+		}
+		w := httptest.NewRecorder()
+		s.mux.ServeHTTP(w, req)
+		if w.Code != tc.want {
+			t.Errorf("GET %s with token %q: got %d, want %d", tc.path, tc.token, w.Code, tc.want)
+		}
+	}
+}
+
+func TestOptionsValidate(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		opts Options
+		errs int
+	}{
+		{name: "plain", opts: Options{Name: "ingest", BindAddress: ":8081"}},
+		{name: "tls", opts: Options{Name: "ingest", BindAddress: ":8443", CertFile: "tls.crt", KeyFile: "tls.key", ClientCAFile: "ca.crt"}},
+		{name: "missing key", opts: Options{Name: "ingest", CertFile: "tls.crt"}, errs: 1},
+		{name: "authn without tls", opts: Options{Name: "ingest", Authenticate: true}, errs: 1},
+		{name: "authz without authn", opts: Options{Name: "ingest", CertFile: "tls.crt", KeyFile: "tls.key", Authorize: true}, errs: 1},
+	} {
+		if errs := tc.opts.Validate(); len(errs) != tc.errs {
+			t.Errorf("%s: got errors %v, want %d", tc.name, errs, tc.errs)
+		}
+	}
+}
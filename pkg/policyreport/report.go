@@ -0,0 +1,217 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	core "k8s.io/api/core/v1"
+)
+
+const (
+	// source of the results, as shown by the policy report dashboards
+	source   = "Falco"
+	category = "SI - System and Information Integrity"
+)
+
+// Statuses of a result.
+const (
+	statusFail = "fail"
+	statusWarn = "warn"
+)
+
+// Result is a result of a wgpolicyk8s.io/v1alpha2 PolicyReport, for the events of a rule raised
+// for a resource.
+type Result struct {
+	Source     string                 `json:"source"`
+	Policy     string                 `json:"policy"`
+	Category   string                 `json:"category,omitempty"`
+	Severity   string                 `json:"severity,omitempty"`
+	Timestamp  Timestamp              `json:"timestamp"`
+	Result     string                 `json:"result"`
+	Scored     bool                   `json:"scored"`
+	Message    string                 `json:"message,omitempty"`
+	Resources  []core.ObjectReference `json:"resources,omitempty"`
+	Properties map[string]string      `json:"properties,omitempty"`
+}
+
+// Timestamp is the time of a result, as a protobuf timestamp.
+type Timestamp struct {
+	Seconds int64 `json:"seconds"`
+	Nanos   int32 `json:"nanos"`
+}
+
+// reportFields are the fields of a report written by the reporter.
+type reportFields struct {
+	Results []Result `json:"results"`
+	Summary Summary  `json:"summary"`
+}
+
+// Summary is the number of results of a report by status.
+type Summary struct {
+	Pass  int `json:"pass"`
+	Fail  int `json:"fail"`
+	Warn  int `json:"warn"`
+	Error int `json:"error"`
+	Skip  int `json:"skip"`
+}
+
+// severity returns the policy report severity of the priority.
+func severity(p types.PriorityType) string {
+	switch {
+	case p >= types.Critical:
+		return "critical"
+	case p == types.Error:
+		return "high"
+	case p == types.Warning:
+		return "medium"
+	case p == types.Notice:
+		return "low"
+	default:
+		return "info"
+	}
+}
+
+// resultKey identifies the result of the events of a rule raised for a resource.
+type resultKey struct {
+	rule     string
+	resource core.ObjectReference
+}
+
+// entry is a result with the highest priority, time of the most recent event and number of
+// occurrences of its events.
+type entry struct {
+	result   Result
+	priority types.PriorityType
+	time     time.Time
+	count    int64
+}
+
+// report collects the results of a namespace, or of the cluster for an empty namespace.
+type report struct {
+	results map[resultKey]*entry
+}
+
+// add adds the event raised for the resource to its result. The result keeps the message of
+// the most recent event and the highest priority.
+func (r *report) add(fe *api.FalcoEvent, resource *core.ObjectReference) {
+	spec := fe.Spec
+	p := types.Priority(string(spec.Priority))
+	t := spec.Time.Time
+	if fe.Status.LastSeen != nil {
+		t = fe.Status.LastSeen.Time
+	}
+	count := fe.Status.Count
+	if count < 1 {
+		count = 1
+	}
+
+	key := resultKey{rule: spec.Rule}
+	if resource != nil {
+		key.resource = *resource
+	}
+	res, ok := r.results[key]
+	if !ok {
+		res = &entry{result: Result{
+			Source:     source,
+			Policy:     spec.Rule,
+			Category:   category,
+			Scored:     true,
+			Properties: map[string]string{},
+		}}
+		if resource != nil {
+			res.result.Resources = []core.ObjectReference{*resource}
+		}
+		r.results[key] = res
+	}
+	if p > res.priority {
+		res.priority = p
+		res.result.Severity = severity(p)
+		res.result.Result = statusWarn
+		if p >= types.Warning {
+			res.result.Result = statusFail
+		}
+		res.result.Properties["priority"] = p.String()
+	}
+	if !t.Before(res.time) {
+		res.time = t
+		res.result.Timestamp = Timestamp{Seconds: t.Unix(), Nanos: int32(t.Nanosecond())}
+		res.result.Message = spec.Output
+		res.result.Properties["falcoevent"] = fe.Name
+		if spec.Source != "" {
+			res.result.Properties["source"] = spec.Source
+		}
+		if len(spec.Tags) > 0 {
+			res.result.Properties["tags"] = strings.Join(spec.Tags, ",")
+		}
+	}
+	res.count += count
+}
+
+// Results returns the results of the report, at most maxResults of them if positive. Without
+// pruneByPriority, the most recent results are kept, else the ones of the highest priority.
+// The results are sorted by policy and resource.
+func (r *report) Results(maxResults int, pruneByPriority bool) ([]Result, Summary) {
+	entries := make([]*entry, 0, len(r.results))
+	for _, e := range r.results {
+		entries = append(entries, e)
+	}
+	if maxResults > 0 && len(entries) > maxResults {
+		sort.Slice(entries, func(i, j int) bool {
+			if pruneByPriority && entries[i].priority != entries[j].priority {
+				return entries[i].priority > entries[j].priority
+			}
+			return entries[i].time.After(entries[j].time)
+		})
+		entries = entries[:maxResults]
+	}
+	out := make([]Result, 0, len(entries))
+	for _, e := range entries {
+		e.result.Properties["count"] = strconv.FormatInt(e.count, 10)
+		out = append(out, e.result)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Policy != out[j].Policy {
+			return out[i].Policy < out[j].Policy
+		}
+		return resourceName(out[i]) < resourceName(out[j])
+	})
+
+	var s Summary
+	for _, res := range out {
+		if res.Result == statusFail {
+			s.Fail++
+		} else {
+			s.Warn++
+		}
+	}
+	return out, s
+}
+
+func resourceName(r Result) string {
+	if len(r.Resources) == 0 {
+		return ""
+	}
+	ref := r.Resources[0]
+	return ref.Kind + "/" + ref.Namespace + "/" + ref.Name
+}
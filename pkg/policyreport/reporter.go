@@ -0,0 +1,289 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+)
+
+const (
+	DefaultInterval  = 5 * time.Minute
+	DefaultPageSize  = 500
+	DefaultMaxEvents = 1000
+
+	// ReportName is the name of the PolicyReports and of the ClusterPolicyReport.
+	ReportName = "falco-policy-report"
+
+	labelManagedBy = "app.kubernetes.io/managed-by"
+	managedBy      = "falco-ui-server"
+	// maxOwnerDepth bounds the controllers followed from a pod to its workload
+	maxOwnerDepth = 3
+)
+
+var (
+	policyReportGVK        = schema.GroupVersionKind{Group: "wgpolicyk8s.io", Version: "v1alpha2", Kind: "PolicyReport"}
+	clusterPolicyReportGVK = schema.GroupVersionKind{Group: "wgpolicyk8s.io", Version: "v1alpha2", Kind: "ClusterPolicyReport"}
+)
+
+// Reporter maintains a wgpolicyk8s.io PolicyReport per namespace and a ClusterPolicyReport for
+// the host events, built from the stored FalcoEvents. The events triaged as Resolved or
+// FalsePositive are not reported. It runs on the leader only.
+type Reporter struct {
+	kc     client.Client
+	reader client.Reader
+	cfg    types.PolicyReportConfig
+
+	minPriority types.PriorityType
+	interval    time.Duration
+	// owners are the workloads of the pods, by pod UID, as resolved by the last pass
+	owners map[ktypes.UID]*core.ObjectReference
+}
+
+var _ manager.LeaderElectionRunnable = &Reporter{}
+
+// New returns a reporter listing the FalcoEvents and writing the reports with kc. The workloads
+// of the pods are read with reader.
+func New(kc client.Client, reader client.Reader, cfg types.PolicyReportConfig) *Reporter {
+	if cfg.MaxEvents <= 0 {
+		cfg.MaxEvents = DefaultMaxEvents
+	}
+	return &Reporter{
+		kc:          kc,
+		reader:      reader,
+		cfg:         cfg,
+		minPriority: types.Priority(cfg.MinimumPriority),
+		interval:    DefaultInterval,
+		owners:      map[ktypes.UID]*core.ObjectReference{},
+	}
+}
+
+func (r *Reporter) NeedLeaderElection() bool {
+	return true
+}
+
+// Start updates the reports every interval until the context is done. It implements
+// manager.Runnable.
+func (r *Reporter) Start(ctx context.Context) error {
+	klog.InfoS("Starts the policy reporter", "interval", r.interval, "maxEvents", r.cfg.MaxEvents, "pruneByPriority", r.cfg.PruneByPriority)
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := r.Run(ctx); err != nil {
+			klog.ErrorS(err, "failed to update the policy reports")
+		}
+	}, r.interval)
+	return nil
+}
+
+// Run builds the reports from the stored FalcoEvents and writes them. The reports of the
+// namespaces without results are deleted.
+func (r *Reporter) Run(ctx context.Context) error {
+	reports, err := r.build(ctx)
+	if err != nil {
+		return err
+	}
+
+	var errs []error
+	for _, gvk := range []schema.GroupVersionKind{policyReportGVK, clusterPolicyReportGVK} {
+		existing, err := r.listReports(ctx, gvk)
+		if meta.IsNoMatchError(err) {
+			klog.V(3).InfoS("PolicyReport CRDs are not installed, skips the reports", "kind", gvk.Kind)
+			return nil
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for ns, rep := range reports {
+			// host events are reported in the ClusterPolicyReport
+			if (ns == "") != (gvk == clusterPolicyReportGVK) {
+				continue
+			}
+			errs = append(errs, r.write(ctx, gvk, ns, rep, existing[ns]))
+			delete(existing, ns)
+		}
+		for _, obj := range existing {
+			if err := r.kc.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
+
+// build collects the results of the stored events, by namespace.
+func (r *Reporter) build(ctx context.Context) (map[string]*report, error) {
+	reports := map[string]*report{}
+	owners := map[ktypes.UID]*core.ObjectReference{}
+	opts := &client.ListOptions{Limit: DefaultPageSize}
+	for {
+		var list api.FalcoEventList
+		if err := r.kc.List(ctx, &list, opts); err != nil {
+			return nil, err
+		}
+		for i := range list.Items {
+			fe := &list.Items[i]
+			if types.Priority(string(fe.Spec.Priority)) < r.minPriority {
+				continue
+			}
+			switch fe.Status.TriageState {
+			case api.TriageStateResolved, api.TriageStateFalsePositive:
+				continue
+			}
+			ns, resource := r.resource(ctx, fe, owners)
+			rep, ok := reports[ns]
+			if !ok {
+				rep = &report{results: map[resultKey]*entry{}}
+				reports[ns] = rep
+			}
+			rep.add(fe, resource)
+		}
+		if list.Continue == "" {
+			break
+		}
+		opts.Continue = list.Continue
+	}
+	r.owners = owners
+	return reports, nil
+}
+
+// resource returns the namespace of the event and the resource it was raised for: the workload
+// of its pod, else its pod, else its namespace. Host events are raised for their node.
+func (r *Reporter) resource(ctx context.Context, fe *api.FalcoEvent, owners map[ktypes.UID]*core.ObjectReference) (string, *core.ObjectReference) {
+	w := fe.Spec.Workload
+	if w == nil || w.Namespace == "" {
+		node := fe.Spec.Nodename
+		if node == "" {
+			node = fe.Spec.Hostname
+		}
+		if node == "" {
+			return "", nil
+		}
+		return "", &core.ObjectReference{APIVersion: "v1", Kind: "Node", Name: node}
+	}
+	if w.Pod == "" {
+		return w.Namespace, &core.ObjectReference{APIVersion: "v1", Kind: "Namespace", Name: w.Namespace}
+	}
+	pod := &core.ObjectReference{APIVersion: "v1", Kind: "Pod", Namespace: w.Namespace, Name: w.Pod, UID: ktypes.UID(w.PodUID)}
+	if w.PodUID == "" {
+		return w.Namespace, pod
+	}
+	uid := ktypes.UID(w.PodUID)
+	if ref, ok := owners[uid]; ok {
+		return w.Namespace, ref
+	}
+	ref, ok := r.owners[uid]
+	if !ok {
+		ref = r.workload(ctx, pod)
+	}
+	owners[uid] = ref
+	return w.Namespace, ref
+}
+
+// workload follows the controllers of the pod up to its workload, eg, from a pod to its
+// ReplicaSet to its Deployment. The pod is its own workload if it is not found.
+func (r *Reporter) workload(ctx context.Context, pod *core.ObjectReference) *core.ObjectReference {
+	ref := pod
+	for i := 0; i < maxOwnerDepth; i++ {
+		obj := &metav1.PartialObjectMetadata{}
+		obj.SetGroupVersionKind(schema.FromAPIVersionAndKind(ref.APIVersion, ref.Kind))
+		if err := r.reader.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, obj); err != nil {
+			if !apierrors.IsNotFound(err) {
+				klog.V(3).ErrorS(err, "failed to get the owner", "kind", ref.Kind, "namespace", ref.Namespace, "name", ref.Name)
+			}
+			return ref
+		}
+		if ref.UID != "" && obj.UID != ref.UID {
+			// the pod was replaced by one with the same name
+			return ref
+		}
+		owner := metav1.GetControllerOf(obj)
+		if owner == nil {
+			return ref
+		}
+		ref = &core.ObjectReference{APIVersion: owner.APIVersion, Kind: owner.Kind, Namespace: ref.Namespace, Name: owner.Name, UID: owner.UID}
+	}
+	return ref
+}
+
+// listReports returns the reports written by the reporter, by namespace.
+func (r *Reporter) listReports(ctx context.Context, gvk schema.GroupVersionKind) (map[string]*unstructured.Unstructured, error) {
+	var list unstructured.UnstructuredList
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	if err := r.kc.List(ctx, &list, client.MatchingLabels{labelManagedBy: managedBy}); err != nil {
+		return nil, err
+	}
+	out := make(map[string]*unstructured.Unstructured, len(list.Items))
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetName() == ReportName {
+			out[obj.GetNamespace()] = obj
+		}
+	}
+	return out, nil
+}
+
+// write creates the report of the namespace, or updates the existing one if its results
+// changed.
+func (r *Reporter) write(ctx context.Context, gvk schema.GroupVersionKind, ns string, rep *report, existing *unstructured.Unstructured) error {
+	results, summary := rep.Results(r.cfg.MaxEvents, r.cfg.PruneByPriority)
+	fields, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&reportFields{Results: results, Summary: summary})
+	if err != nil {
+		return err
+	}
+
+	if existing != nil {
+		if equality.Semantic.DeepEqual(existing.Object["results"], fields["results"]) &&
+			equality.Semantic.DeepEqual(existing.Object["summary"], fields["summary"]) {
+			return nil
+		}
+		existing.Object["results"] = fields["results"]
+		existing.Object["summary"] = fields["summary"]
+		if err := r.kc.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update %s %s/%s: %v", gvk.Kind, ns, ReportName, err)
+		}
+		return nil
+	}
+
+	obj := &unstructured.Unstructured{Object: fields}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetNamespace(ns)
+	obj.SetName(ReportName)
+	obj.SetLabels(map[string]string{labelManagedBy: managedBy})
+	if err := r.kc.Create(ctx, obj); err != nil {
+		return fmt.Errorf("failed to create %s %s/%s: %v", gvk.Kind, ns, ReportName, err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policyreport
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco"
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+	"kubeops.dev/falco-ui-server/pkg/falcotest"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ktypes "k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// fakeClient serves FalcoEvents and stores the reports by namespace.
+type fakeClient struct {
+	client.Client
+
+	events  []api.FalcoEvent
+	reports map[string]*unstructured.Unstructured
+	updates int
+}
+
+func (f *fakeClient) List(_ context.Context, list client.ObjectList, _ ...client.ListOption) error {
+	switch l := list.(type) {
+	case *api.FalcoEventList:
+		l.Items = append([]api.FalcoEvent(nil), f.events...)
+	case *unstructured.UnstructuredList:
+		kind := l.GetKind()
+		l.Items = nil
+		for _, obj := range f.reports {
+			if obj.GetKind()+"List" == kind {
+				l.Items = append(l.Items, *obj.DeepCopy())
+			}
+		}
+	}
+	return nil
+}
+
+func (f *fakeClient) Create(_ context.Context, obj client.Object, _ ...client.CreateOption) error {
+	f.reports[obj.GetNamespace()] = obj.(*unstructured.Unstructured).DeepCopy()
+	return nil
+}
+
+func (f *fakeClient) Update(_ context.Context, obj client.Object, _ ...client.UpdateOption) error {
+	f.updates++
+	f.reports[obj.GetNamespace()] = obj.(*unstructured.Unstructured).DeepCopy()
+	return nil
+}
+
+func (f *fakeClient) Delete(_ context.Context, obj client.Object, _ ...client.DeleteOption) error {
+	delete(f.reports, obj.GetNamespace())
+	return nil
+}
+
+// fakeReader serves the metadata of the objects, by kind and name.
+type fakeReader struct {
+	client.Reader
+
+	objects map[string]metav1.ObjectMeta
+}
+
+func (f fakeReader) Get(_ context.Context, key client.ObjectKey, obj client.Object, _ ...client.GetOption) error {
+	kind := obj.GetObjectKind().GroupVersionKind().Kind
+	m, ok := f.objects[kind+"/"+key.Name]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: kind}, key.Name)
+	}
+	m.DeepCopyInto(&obj.(*metav1.PartialObjectMetadata).ObjectMeta)
+	return nil
+}
+
+func controller(kind, name string) []metav1.OwnerReference {
+	return []metav1.OwnerReference{{APIVersion: "apps/v1", Kind: kind, Name: name, UID: ktypes.UID("uid-" + name), Controller: ptr.To(true)}}
+}
+
+func TestReporter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	node := falcotest.OnNode("node-1")
+	kc := &fakeClient{
+		events: []api.FalcoEvent{
+			falcotest.V1beta1(falcotest.NewEvent("fe-1", "Terminal shell in container", falco.PriorityNotice, now, falcotest.OnPod("shop", "cart-7d4b9c8f6d-x2x9z"), node)),
+			falcotest.V1beta1(falcotest.NewEvent("fe-2", "Terminal shell in container", falco.PriorityCritical, now.Add(time.Minute), falcotest.OnPod("shop", "cart-7d4b9c8f6d-a1b2c"), node)),
+			falcotest.V1beta1(falcotest.NewEvent("fe-3", "Write below etc", falco.PriorityError, now, falcotest.OnPod("shop", "db-0"), node)),
+			falcotest.V1beta1(falcotest.NewEvent("fe-4", "Write below binary dir", falco.PriorityWarning, now, node)),
+			falcotest.V1beta1(falcotest.NewEvent("fe-5", "Debug rule", falco.PriorityDebug, now, falcotest.OnPod("shop", "db-0"), node)),
+		},
+		reports: map[string]*unstructured.Unstructured{},
+	}
+	resolved := falcotest.V1beta1(falcotest.NewEvent("fe-6", "Write below etc", falco.PriorityCritical, now, falcotest.OnPod("billing", "api-0"), node))
+	resolved.Status.TriageState = api.TriageStateResolved
+	kc.events = append(kc.events, resolved)
+	// stale reports of namespaces without events are deleted
+	stale := &unstructured.Unstructured{}
+	stale.SetGroupVersionKind(policyReportGVK)
+	stale.SetNamespace("old")
+	stale.SetName(ReportName)
+	kc.reports["old"] = stale
+
+	reader := fakeReader{objects: map[string]metav1.ObjectMeta{
+		"Pod/cart-7d4b9c8f6d-x2x9z":  {Name: "cart-7d4b9c8f6d-x2x9z", UID: "uid-cart-7d4b9c8f6d-x2x9z", OwnerReferences: controller("ReplicaSet", "cart-7d4b9c8f6d")},
+		"Pod/cart-7d4b9c8f6d-a1b2c":  {Name: "cart-7d4b9c8f6d-a1b2c", UID: "uid-cart-7d4b9c8f6d-a1b2c", OwnerReferences: controller("ReplicaSet", "cart-7d4b9c8f6d")},
+		"ReplicaSet/cart-7d4b9c8f6d": {Name: "cart-7d4b9c8f6d", UID: "uid-cart-7d4b9c8f6d", OwnerReferences: controller("Deployment", "cart")},
+		"Deployment/cart":            {Name: "cart", UID: "uid-cart"},
+	}}
+	r := New(kc, reader, types.PolicyReportConfig{MinimumPriority: "notice", MaxEvents: 1, PruneByPriority: true})
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := kc.reports["old"]; ok {
+		t.Error("stale report is not deleted")
+	}
+	if _, ok := kc.reports["billing"]; ok {
+		t.Error("resolved event is reported")
+	}
+	shop := kc.reports["shop"]
+	if shop == nil {
+		t.Fatal("report of namespace shop is not created")
+	}
+	results, _, _ := unstructured.NestedSlice(shop.Object, "results")
+	// the two pods of the cart Deployment are one result, which outranks the one of db-0
+	if len(results) != 1 {
+		t.Fatalf("got %d results, want 1: %v", len(results), results)
+	}
+	res := results[0].(map[string]any)
+	resources, _, _ := unstructured.NestedSlice(res, "resources")
+	if len(resources) != 1 || resources[0].(map[string]any)["kind"] != "Deployment" || resources[0].(map[string]any)["name"] != "cart" {
+		t.Errorf("got resources %v, want the cart Deployment", resources)
+	}
+	for path, want := range map[string]string{
+		"policy":                "Terminal shell in container",
+		"severity":              "critical",
+		"result":                "fail",
+		"message":               "Terminal shell in container in fe-2",
+		"properties.count":      "2",
+		"properties.falcoevent": "fe-2",
+	} {
+		if got, _, _ := unstructured.NestedString(res, strings.Split(path, ".")...); got != want {
+			t.Errorf("got %s=%q, want %q", path, got, want)
+		}
+	}
+	if fail, _, _ := unstructured.NestedInt64(shop.Object, "summary", "fail"); fail != 1 {
+		t.Errorf("got summary fail=%d, want 1", fail)
+	}
+
+	cluster := kc.reports[""]
+	if cluster == nil || cluster.GetKind() != clusterPolicyReportGVK.Kind {
+		t.Fatalf("ClusterPolicyReport is not created: %v", cluster)
+	}
+	results, _, _ = unstructured.NestedSlice(cluster.Object, "results")
+	if len(results) != 1 {
+		t.Fatalf("got %d cluster results, want 1", len(results))
+	}
+	resources, _, _ = unstructured.NestedSlice(results[0].(map[string]any), "resources")
+	if len(resources) != 1 || resources[0].(map[string]any)["kind"] != "Node" || resources[0].(map[string]any)["name"] != "node-1" {
+		t.Errorf("got cluster resources %v, want node-1", resources)
+	}
+
+	// unchanged reports are not updated
+	if err := r.Run(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if kc.updates != 0 {
+		t.Errorf("got %d updates of unchanged reports", kc.updates)
+	}
+}
+
+func TestReportPruning(t *testing.T) {
+	now := time.Now()
+	rep := &report{results: map[resultKey]*entry{}}
+	for i, p := range []api.Priority{api.PriorityCritical, api.PriorityNotice, api.PriorityWarning} {
+		fe := falcotest.V1beta1(falcotest.NewEvent("fe", string(p), falco.Priority(p), now.Add(time.Duration(i)*time.Minute), falcotest.OnPod("shop", "")))
+		rep.add(&fe, nil)
+	}
+	results, _ := rep.Results(2, true)
+	if len(results) != 2 || results[0].Policy != string(api.PriorityCritical) || results[1].Policy != string(api.PriorityWarning) {
+		t.Errorf("got results %v, want the Critical and Warning ones", results)
+	}
+	// without pruning by priority, the most recent results are kept
+	results, _ = rep.Results(2, false)
+	if len(results) != 2 || results[0].Policy != string(api.PriorityNotice) || results[1].Policy != string(api.PriorityWarning) {
+		t.Errorf("got results %v, want the Notice and Warning ones", results)
+	}
+}
@@ -0,0 +1,59 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+const metricPrefix = "falco_appscode_com_"
+
+var (
+	quotaUsage = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricPrefix + "quota_events",
+		Help: "Number of stored FalcoEvents per namespace or node",
+	}, []string{"scope", "name"})
+
+	quotaLimit = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: metricPrefix + "quota_limit_events",
+		Help: "Maximum number of stored FalcoEvents per namespace or node, zero if unlimited",
+	}, []string{"scope", "name"})
+
+	quotaEvictions = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: metricPrefix + "quota_evictions_total",
+		Help: "Number of FalcoEvents evicted to keep a namespace or node within its quota",
+	}, []string{"scope", "name"})
+)
+
+func init() {
+	metrics.Registry.MustRegister(quotaUsage, quotaLimit, quotaEvictions)
+}
+
+func setUsage(k key, count, limit int) {
+	quotaUsage.WithLabelValues(string(k.scope), k.name).Set(float64(count))
+	quotaLimit.WithLabelValues(string(k.scope), k.name).Set(float64(limit))
+}
+
+func deleteUsage(k key) {
+	quotaUsage.DeleteLabelValues(string(k.scope), k.name)
+	quotaLimit.DeleteLabelValues(string(k.scope), k.name)
+}
+
+func recordEviction(k key) {
+	quotaEvictions.WithLabelValues(string(k.scope), k.name).Inc()
+}
@@ -0,0 +1,345 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+	"k8s.io/klog/v2"
+)
+
+// Scope is the kind of owner a FalcoEvent is accounted to.
+type Scope string
+
+const (
+	// ScopeNamespace accounts events of workloads to their namespace.
+	ScopeNamespace Scope = "namespace"
+	// ScopeNode accounts host events, ie, events without a namespace, to their node.
+	ScopeNode Scope = "node"
+)
+
+// Config holds the caps on the number of stored FalcoEvents. Zero means unlimited.
+type Config struct {
+	// NamespaceLimit is the cap for every namespace without an entry in NamespaceLimits.
+	NamespaceLimit  int
+	NamespaceLimits map[string]int
+	// NodeLimit is the cap of host events for every node without an entry in NodeLimits.
+	NodeLimit  int
+	NodeLimits map[string]int
+	// PerReplica enforces the caps on every replica, for a storage that is local to each replica.
+	// Otherwise the storage is shared and only the leader evicts events, while every replica
+	// tracks the usage.
+	PerReplica bool
+}
+
+// Enabled returns true if any cap is configured.
+func (c Config) Enabled() bool {
+	return c.NamespaceLimit > 0 || c.NodeLimit > 0 || len(c.NamespaceLimits) > 0 || len(c.NodeLimits) > 0
+}
+
+func (c Config) limit(k key) int {
+	limits, def := c.NamespaceLimits, c.NamespaceLimit
+	if k.scope == ScopeNode {
+		limits, def = c.NodeLimits, c.NodeLimit
+	}
+	if l, ok := limits[k.name]; ok {
+		return l
+	}
+	return def
+}
+
+// Store is the FalcoEvent storage the quotas are enforced on.
+type Store interface {
+	rest.Watcher
+	rest.GracefulDeleter
+}
+
+type key struct {
+	scope Scope
+	name  string
+}
+
+type eventInfo struct {
+	priority types.PriorityType
+	time     time.Time
+}
+
+type bucket struct {
+	events   map[string]eventInfo
+	evicting map[string]bool
+	evicted  int64
+}
+
+// Enforcer keeps the number of FalcoEvents per namespace and per node within their caps.
+// When a cap is exceeded, the lowest priority events are evicted first, oldest first among
+// events of the same priority.
+type Enforcer struct {
+	cfg     Config
+	store   Store
+	elected <-chan struct{}
+
+	mu      sync.Mutex
+	owners  map[string]key
+	buckets map[key]*bucket
+}
+
+// NewEnforcer returns an Enforcer of the caps on the store. With a shared storage, events are
+// only evicted once elected is closed, when the replica is elected as the leader. A nil elected
+// evicts right away.
+func NewEnforcer(cfg Config, store Store, elected <-chan struct{}) *Enforcer {
+	return &Enforcer{
+		cfg:     cfg,
+		store:   store,
+		elected: elected,
+		owners:  map[string]key{},
+		buckets: map[key]*bucket{},
+	}
+}
+
+// NeedLeaderElection returns false, every replica tracks the usage it serves. The events of a
+// shared storage are only evicted by the leader, so the replicas do not evict the same events
+// concurrently.
+func (e *Enforcer) NeedLeaderElection() bool {
+	return false
+}
+
+// evicting returns true if the replica evicts the events exceeding the caps.
+func (e *Enforcer) evicting() bool {
+	if e.cfg.PerReplica || e.elected == nil {
+		return true
+	}
+	select {
+	case <-e.elected:
+		return true
+	default:
+		return false
+	}
+}
+
+// Start watches the FalcoEvent storage until the context is done. It implements manager.Runnable.
+func (e *Enforcer) Start(ctx context.Context) error {
+	klog.Infoln("Starts the FalcoEvent quota enforcer")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := e.watch(ctx); err != nil {
+			klog.ErrorS(err, "failed to watch FalcoEvents for quota enforcement")
+		}
+	}, 5*time.Second)
+	return nil
+}
+
+func (e *Enforcer) watch(ctx context.Context) error {
+	ctx = genericapirequest.WithNamespace(ctx, metav1.NamespaceNone)
+	w, err := e.store.Watch(ctx, &metainternalversion.ListOptions{})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+
+	// the watch is restarted once the replica is elected, to evict the events exceeding the caps
+	var elected <-chan struct{}
+	if !e.evicting() {
+		elected = e.elected
+	}
+	// the watch starts with the current events, so the usage is rebuilt from scratch
+	e.reset()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-elected:
+			return nil
+		case ev, ok := <-w.ResultChan():
+			if !ok {
+				return nil
+			}
+			switch ev.Type {
+			case watch.Added, watch.Modified:
+				if fe, ok := ev.Object.(*api.FalcoEvent); ok {
+					e.evict(ctx, e.observe(fe))
+				}
+			case watch.Deleted:
+				if fe, ok := ev.Object.(*api.FalcoEvent); ok {
+					e.forget(fe.Name)
+				}
+			case watch.Error:
+				return apierrors.FromObject(ev.Object)
+			}
+		}
+	}
+}
+
+func (e *Enforcer) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for k := range e.buckets {
+		deleteUsage(k)
+	}
+	e.owners = map[string]key{}
+	e.buckets = map[key]*bucket{}
+}
+
+func ownerOf(fe *api.FalcoEvent) (key, bool) {
+	if ns := fe.Labels[api.LabelNamespaceName]; ns != "" {
+		return key{scope: ScopeNamespace, name: ns}, true
+	}
+	if node := fe.Labels[api.LabelNodeName]; node != "" {
+		return key{scope: ScopeNode, name: node}, true
+	}
+	return key{}, false
+}
+
+// observe records an event and returns the names of the events to evict.
+func (e *Enforcer) observe(fe *api.FalcoEvent) []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.forgetLocked(fe.Name)
+	k, ok := ownerOf(fe)
+	if !ok {
+		return nil
+	}
+	b, ok := e.buckets[k]
+	if !ok {
+		b = &bucket{events: map[string]eventInfo{}, evicting: map[string]bool{}}
+		e.buckets[k] = b
+	}
+	e.owners[fe.Name] = k
+	b.events[fe.Name] = eventInfo{
+		priority: types.Priority(string(fe.Spec.Priority)),
+		time:     fe.Spec.Time.Time,
+	}
+	setUsage(k, len(b.events), e.cfg.limit(k))
+
+	limit := e.cfg.limit(k)
+	excess := len(b.events) - len(b.evicting) - limit
+	if limit <= 0 || excess <= 0 || !e.evicting() {
+		return nil
+	}
+	candidates := make([]string, 0, len(b.events))
+	for name := range b.events {
+		if !b.evicting[name] {
+			candidates = append(candidates, name)
+		}
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, c := b.events[candidates[i]], b.events[candidates[j]]
+		if a.priority != c.priority {
+			return a.priority < c.priority
+		}
+		if !a.time.Equal(c.time) {
+			return a.time.Before(c.time)
+		}
+		return candidates[i] < candidates[j]
+	})
+	victims := candidates[:excess]
+	for _, name := range victims {
+		b.evicting[name] = true
+	}
+	return victims
+}
+
+func (e *Enforcer) evict(ctx context.Context, names []string) {
+	for _, name := range names {
+		_, _, err := e.store.Delete(ctx, name, rest.ValidateAllObjectFunc, &metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			klog.ErrorS(err, "failed to evict FalcoEvent", "name", name)
+			e.mu.Lock()
+			if k, ok := e.owners[name]; ok {
+				delete(e.buckets[k].evicting, name)
+			}
+			e.mu.Unlock()
+			continue
+		}
+
+		e.mu.Lock()
+		if k, ok := e.owners[name]; ok {
+			e.buckets[k].evicted++
+			recordEviction(k)
+		}
+		e.forgetLocked(name)
+		e.mu.Unlock()
+	}
+}
+
+func (e *Enforcer) forget(name string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.forgetLocked(name)
+}
+
+func (e *Enforcer) forgetLocked(name string) {
+	k, ok := e.owners[name]
+	if !ok {
+		return
+	}
+	delete(e.owners, name)
+	b := e.buckets[k]
+	delete(b.events, name)
+	delete(b.evicting, name)
+	if len(b.events) == 0 && b.evicted == 0 {
+		delete(e.buckets, k)
+		deleteUsage(k)
+		return
+	}
+	setUsage(k, len(b.events), e.cfg.limit(k))
+}
+
+// Usage is the number of stored FalcoEvents of a namespace or node.
+type Usage struct {
+	Scope   Scope  `json:"scope"`
+	Name    string `json:"name"`
+	Count   int    `json:"count"`
+	Limit   int    `json:"limit,omitempty"`
+	Evicted int64  `json:"evicted,omitempty"`
+}
+
+// Usage returns the current usage of all namespaces and nodes, sorted by scope and name.
+func (e *Enforcer) Usage() []Usage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	out := make([]Usage, 0, len(e.buckets))
+	for k, b := range e.buckets {
+		out = append(out, Usage{
+			Scope:   k.scope,
+			Name:    k.name,
+			Count:   len(b.events),
+			Limit:   e.cfg.limit(k),
+			Evicted: b.evicted,
+		})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Scope != out[j].Scope {
+			return out[i].Scope < out[j].Scope
+		}
+		return out[i].Name < out[j].Name
+	})
+	return out
+}
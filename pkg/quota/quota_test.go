@@ -0,0 +1,90 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/pkg/falcotest"
+)
+
+func TestEnforcer(t *testing.T) {
+	store := &falcotest.Store{}
+	e := NewEnforcer(Config{
+		NamespaceLimit:  2,
+		NamespaceLimits: map[string]int{"kube-system": 10},
+		NodeLimit:       1,
+	}, store, nil)
+
+	now := time.Now()
+	for _, fe := range []*api.FalcoEvent{
+		falcotest.NewEvent("fe-1", "shell", api.PriorityCritical, now.Add(-3*time.Minute), falcotest.InNamespace("noisy"), falcotest.OnNode("node-1")),
+		falcotest.NewEvent("fe-2", "shell", api.PriorityNotice, now.Add(-2*time.Minute), falcotest.InNamespace("noisy"), falcotest.OnNode("node-1")),
+		falcotest.NewEvent("fe-3", "shell", api.PriorityNotice, now.Add(-time.Minute), falcotest.InNamespace("noisy"), falcotest.OnNode("node-1")),
+		falcotest.NewEvent("fe-4", "shell", api.PriorityCritical, now, falcotest.InNamespace("noisy"), falcotest.OnNode("node-1")),
+		falcotest.NewEvent("fe-5", "shell", api.PriorityNotice, now, falcotest.InNamespace("kube-system"), falcotest.OnNode("node-1")),
+		falcotest.NewEvent("fe-6", "shell", api.PriorityNotice, now.Add(-time.Minute), falcotest.OnNode("node-1")),
+		falcotest.NewEvent("fe-7", "shell", api.PriorityNotice, now, falcotest.OnNode("node-1")),
+	} {
+		e.evict(context.TODO(), e.observe(fe))
+	}
+
+	deleted := append([]string(nil), store.Deleted...)
+	sort.Strings(deleted)
+	// the notice events of the noisy namespace go first, then the oldest host event
+	if want := []string{"fe-2", "fe-3", "fe-6"}; !reflect.DeepEqual(deleted, want) {
+		t.Errorf("evicted %v, want %v", deleted, want)
+	}
+
+	want := []Usage{
+		{Scope: ScopeNamespace, Name: "kube-system", Count: 1, Limit: 10},
+		{Scope: ScopeNamespace, Name: "noisy", Count: 2, Limit: 2, Evicted: 2},
+		{Scope: ScopeNode, Name: "node-1", Count: 1, Limit: 1, Evicted: 1},
+	}
+	if got := e.Usage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Usage() = %+v, want %+v", got, want)
+	}
+}
+
+func TestEnforcerNotElected(t *testing.T) {
+	store := &falcotest.Store{}
+	elected := make(chan struct{})
+	e := NewEnforcer(Config{NamespaceLimit: 1}, store, elected)
+
+	now := time.Now()
+	e.evict(context.TODO(), e.observe(falcotest.NewEvent("fe-1", "shell", api.PriorityNotice, now.Add(-time.Minute), falcotest.InNamespace("noisy"))))
+	e.evict(context.TODO(), e.observe(falcotest.NewEvent("fe-2", "shell", api.PriorityNotice, now, falcotest.InNamespace("noisy"))))
+	// the usage is tracked, but only the leader evicts events
+	if len(store.Deleted) != 0 {
+		t.Errorf("evicted %v before the election", store.Deleted)
+	}
+	want := []Usage{{Scope: ScopeNamespace, Name: "noisy", Count: 2, Limit: 1}}
+	if got := e.Usage(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Usage() = %+v, want %+v", got, want)
+	}
+
+	close(elected)
+	e.evict(context.TODO(), e.observe(falcotest.NewEvent("fe-2", "shell", api.PriorityNotice, now, falcotest.InNamespace("noisy"))))
+	if want := []string{"fe-1"}; !reflect.DeepEqual(store.Deleted, want) {
+		t.Errorf("evicted %v after the election, want %v", store.Deleted, want)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoattackmatrix
+
+import (
+	"context"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	apiv1beta1 "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/attack"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metainternalversion "k8s.io/apimachinery/pkg/apis/meta/internalversion"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+// Index is the FalcoEvent index the matrices are built from.
+type Index interface {
+	attack.Index
+	Synced() bool
+}
+
+// REST serves the FalcoAttackMatrices. They are computed from the index on every request
+// and never stored, so the resource is read-only. The name of a matrix is its window.
+type REST struct {
+	idx Index
+	rest.TableConvertor
+}
+
+var (
+	_ rest.Storage              = &REST{}
+	_ rest.Scoper               = &REST{}
+	_ rest.Getter               = &REST{}
+	_ rest.Lister               = &REST{}
+	_ rest.SingularNameProvider = &REST{}
+	_ rest.ShortNamesProvider   = &REST{}
+	_ rest.CategoriesProvider   = &REST{}
+)
+
+func NewREST(idx Index) *REST {
+	return &REST{
+		idx:            idx,
+		TableConvertor: NewTableConvertor(api.Resource(apiv1beta1.ResourceFalcoAttackMatrices)),
+	}
+}
+
+// New creates a new FalcoAttackMatrix object.
+func (r *REST) New() runtime.Object {
+	return &api.FalcoAttackMatrix{}
+}
+
+// Destroy cleans up resources on shutdown.
+func (r *REST) Destroy() {}
+
+func (r *REST) NamespaceScoped() bool {
+	return false
+}
+
+// GetSingularName implements rest.SingularNameProvider
+func (r *REST) GetSingularName() string {
+	return apiv1beta1.ResourceFalcoAttackMatrix
+}
+
+// ShortNames implements the ShortNamesProvider interface. Returns a list of short names for a resource.
+func (r *REST) ShortNames() []string {
+	return []string{"fam"}
+}
+
+// Categories implements the CategoriesProvider interface. Returns a list of categories a resource is part of.
+func (r *REST) Categories() []string {
+	return []string{"falco"}
+}
+
+// Get returns the matrix of the window named by name, eg, 24h.
+func (r *REST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	window, err := attack.ParseWindow(name)
+	if err != nil {
+		return nil, apierrors.NewBadRequest(err.Error())
+	}
+	if !r.idx.Synced() {
+		return nil, apierrors.NewServiceUnavailable("FalcoEvent index is not synced")
+	}
+	return attack.Build(r.idx, name, window, time.Now()), nil
+}
+
+// NewList returns an empty FalcoAttackMatrixList.
+func (r *REST) NewList() runtime.Object {
+	return &api.FalcoAttackMatrixList{}
+}
+
+// List returns the matrices of the default windows.
+func (r *REST) List(ctx context.Context, options *metainternalversion.ListOptions) (runtime.Object, error) {
+	if !r.idx.Synced() {
+		return nil, apierrors.NewServiceUnavailable("FalcoEvent index is not synced")
+	}
+	now := time.Now()
+	out := &api.FalcoAttackMatrixList{}
+	for _, name := range attack.DefaultWindows {
+		window, err := attack.ParseWindow(name)
+		if err != nil {
+			return nil, err
+		}
+		out.Items = append(out.Items, *attack.Build(r.idx, name, window, now))
+	}
+	return out, nil
+}
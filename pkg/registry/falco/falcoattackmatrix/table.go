@@ -0,0 +1,139 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoattackmatrix
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+type defaultTableConvertor struct {
+	defaultQualifiedResource schema.GroupResource
+}
+
+// NewTableConvertor creates a default convertor; the provided resource is used for error messages
+// if no resource info can be determined from the context passed to ConvertToTable.
+func NewTableConvertor(defaultQualifiedResource schema.GroupResource) rest.TableConvertor {
+	return defaultTableConvertor{defaultQualifiedResource: defaultQualifiedResource}
+}
+
+func (c defaultTableConvertor) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	var table metav1.Table
+	fn := func(obj runtime.Object) error {
+		o, ok := obj.(*api.FalcoAttackMatrix)
+		if !ok {
+			resource := c.defaultQualifiedResource
+			if info, ok := genericapirequest.RequestInfoFrom(ctx); ok {
+				resource = schema.GroupResource{Group: info.APIGroup, Resource: info.Resource}
+			}
+			return errNotAcceptable{resource: resource}
+		}
+
+		var tactics int
+		techniques := sets.New[string]()
+		top, topEvents := "", int64(0)
+		for _, t := range o.Status.Tactics {
+			if t.Events > 0 {
+				tactics++
+			}
+			for _, tt := range t.Techniques {
+				techniques.Insert(tt.ID)
+				if tt.Events > topEvents {
+					top, topEvents = tt.ID, tt.Events
+					if tt.Name != "" {
+						top += " " + tt.Name
+					}
+				}
+			}
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{
+				o.Name,
+				o.Status.Events,
+				o.Status.UnmappedEvents,
+				fmt.Sprintf("%d/%d", tactics, len(o.Status.Tactics)),
+				int64(techniques.Len()),
+				top,
+				o.Status.Since.UTC().Format(time.RFC3339),
+			},
+			Object: runtime.RawExtension{Object: obj},
+		})
+		return nil
+	}
+	switch {
+	case meta.IsListType(object):
+		if err := meta.EachListItem(object, fn); err != nil {
+			return nil, err
+		}
+	default:
+		if err := fn(object); err != nil {
+			return nil, err
+		}
+	}
+	if m, err := meta.ListAccessor(object); err == nil {
+		table.ResourceVersion = m.GetResourceVersion()
+		table.Continue = m.GetContinue()
+		table.RemainingItemCount = m.GetRemainingItemCount()
+	} else {
+		if m, err := meta.CommonAccessor(object); err == nil {
+			table.ResourceVersion = m.GetResourceVersion()
+		}
+	}
+	if opt, ok := tableOptions.(*metav1.TableOptions); !ok || !opt.NoHeaders {
+		table.ColumnDefinitions = []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name", Description: "Window of the matrix"},
+			{Name: "Events", Type: "integer", Description: "Events in the window"},
+			{Name: "Unmapped", Type: "integer", Description: "Events without ATT&CK tags"},
+			{Name: "Tactics", Type: "string", Description: "Tactics with events"},
+			{Name: "Techniques", Type: "integer", Description: "Techniques with events"},
+			{Name: "Top Technique", Type: "string", Description: "Technique with the most events"},
+			{Name: "Since", Type: "string", Description: "Start of the window", Priority: 1},
+		}
+	}
+	return &table, nil
+}
+
+// errNotAcceptable indicates the resource doesn't support Table conversion
+type errNotAcceptable struct {
+	resource schema.GroupResource
+}
+
+func (e errNotAcceptable) Error() string {
+	return fmt.Sprintf("the resource %s does not support being converted to a Table", e.resource)
+}
+
+func (e errNotAcceptable) Status() metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReason("NotAcceptable"),
+		Message: e.Error(),
+	}
+}
@@ -0,0 +1,49 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoattackmatrix
+
+import (
+	"context"
+	"testing"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertToTable(t *testing.T) {
+	m := &api.FalcoAttackMatrix{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster"},
+		Status: api.FalcoAttackMatrixStatus{
+			Events: 2,
+			Tactics: []api.AttackTactic{{
+				ID:         "TA0002",
+				Events:     2,
+				Techniques: []api.AttackTechnique{{ID: "T1059", Events: 2}},
+			}},
+		},
+	}
+	table, err := NewTableConvertor(api.Resource("falcoattackmatrices")).ConvertToTable(context.TODO(), m, &metav1.TableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the cells must be JSON values, the table is deep copied before it is served
+	table = table.DeepCopy()
+	if got := table.Rows[0].Cells[4]; got != int64(1) {
+		t.Errorf("techniques cell = %v, want 1", got)
+	}
+}
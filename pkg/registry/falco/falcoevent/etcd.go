@@ -17,22 +17,42 @@ limitations under the License.
 package request
 
 import (
+	"context"
+
 	api "kubeops.dev/falco-ui-server/apis/falco"
 	apiv1alpha1 "kubeops.dev/falco-ui-server/apis/falco/v1alpha1"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apiserver/pkg/registry/generic"
 	genericregistry "k8s.io/apiserver/pkg/registry/generic/registry"
 	"k8s.io/apiserver/pkg/registry/rest"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
 )
 
-// ControllerStorage includes dummy storage for FalcoEvents and for Status subresource.
+// ControllerStorage includes storage for FalcoEvents and for Status subresource.
 type ControllerStorage struct {
 	Controller *REST
+	Status     *StatusREST
+}
+
+// Config holds the settings of the FalcoEvent storage.
+type Config struct {
+	// IngestUsers are allowed to modify the spec of existing FalcoEvents.
+	IngestUsers []string
+	// Columns are additional output field columns shown by kubectl.
+	Columns []OutputFieldColumn
 }
 
-func NewStorage(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*REST, error) {
-	return NewREST(scheme, optsGetter)
+func NewStorage(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter, cfg Config) (ControllerStorage, error) {
+	controllerREST, statusREST, err := NewREST(scheme, optsGetter, cfg)
+	if err != nil {
+		return ControllerStorage{}, err
+	}
+	return ControllerStorage{
+		Controller: controllerREST,
+		Status:     statusREST,
+	}, nil
 }
 
 type REST struct {
@@ -40,8 +60,8 @@ type REST struct {
 }
 
 // NewREST returns a RESTStorage object that will work against replication controllers.
-func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*REST, error) {
-	strategy := NewStrategy(scheme)
+func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter, cfg Config) (*REST, *StatusREST, error) {
+	strategy := NewStrategy(scheme, cfg.IngestUsers...)
 
 	store := &genericregistry.Store{
 		NewFunc:                   func() runtime.Object { return &api.FalcoEvent{} },
@@ -55,11 +75,11 @@ func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*RES
 		DeleteStrategy:      strategy,
 		ResetFieldsStrategy: strategy,
 
-		TableConvertor: NewTableConvertor(api.Resource(apiv1alpha1.ResourceFalcoEvents)),
+		TableConvertor: NewTableConvertor(api.Resource(apiv1alpha1.ResourceFalcoEvents), cfg.Columns...),
 	}
 	options := &generic.StoreOptions{RESTOptions: optsGetter, AttrFunc: GetAttrs}
 	if err := store.CompleteWithOptions(options); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	statusStrategy := statusStrategy{strategy: strategy}
@@ -67,7 +87,7 @@ func NewREST(scheme *runtime.Scheme, optsGetter generic.RESTOptionsGetter) (*RES
 	statusStore.UpdateStrategy = statusStrategy
 	statusStore.ResetFieldsStrategy = statusStrategy
 
-	return &REST{store}, nil
+	return &REST{store}, &StatusREST{store: &statusStore}, nil
 }
 
 // Implement ShortNamesProvider
@@ -85,3 +105,53 @@ var _ rest.CategoriesProvider = &REST{}
 func (r *REST) Categories() []string {
 	return []string{"falco"}
 }
+
+// StatusREST implements the REST endpoint for changing the status of a FalcoEvent.
+type StatusREST struct {
+	store *genericregistry.Store
+}
+
+var (
+	_ rest.Patcher              = &StatusREST{}
+	_ rest.Storage              = &StatusREST{}
+	_ rest.ResetFieldsStrategy  = &StatusREST{}
+	_ rest.TableConvertor       = &StatusREST{}
+	_ rest.SingularNameProvider = &StatusREST{}
+)
+
+// New creates a new FalcoEvent object.
+func (r *StatusREST) New() runtime.Object {
+	return &api.FalcoEvent{}
+}
+
+// Destroy cleans up resources on shutdown.
+func (r *StatusREST) Destroy() {
+	// Given that underlying store is shared with REST,
+	// we don't destroy it here explicitly.
+}
+
+// Get retrieves the object from the storage. It is required to support Patch.
+func (r *StatusREST) Get(ctx context.Context, name string, options *metav1.GetOptions) (runtime.Object, error) {
+	return r.store.Get(ctx, name, options)
+}
+
+// Update alters the status subset of an object.
+func (r *StatusREST) Update(ctx context.Context, name string, objInfo rest.UpdatedObjectInfo, createValidation rest.ValidateObjectFunc, updateValidation rest.ValidateObjectUpdateFunc, forceAllowCreate bool, options *metav1.UpdateOptions) (runtime.Object, bool, error) {
+	// We are explicitly setting forceAllowCreate to false in the call to the underlying storage because
+	// subresources should never allow create on update.
+	return r.store.Update(ctx, name, objInfo, createValidation, updateValidation, false, options)
+}
+
+// GetResetFields implements rest.ResetFieldsStrategy
+func (r *StatusREST) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
+	return r.store.GetResetFields()
+}
+
+func (r *StatusREST) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	return r.store.ConvertToTable(ctx, object, tableOptions)
+}
+
+// GetSingularName implements rest.SingularNameProvider
+func (r *StatusREST) GetSingularName() string {
+	return r.store.GetSingularName()
+}
@@ -19,15 +19,18 @@ package request
 import (
 	"context"
 	"fmt"
+	"time"
 
 	api "kubeops.dev/falco-ui-server/apis/falco"
 
 	corev1 "k8s.io/api/core/v1"
 	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
 	"k8s.io/apiserver/pkg/registry/generic"
@@ -37,15 +40,24 @@ import (
 	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
 )
 
-// NewStrategy creates and returns a strategy instance
-func NewStrategy(typer runtime.ObjectTyper) strategy {
-	return strategy{typer, names.SimpleNameGenerator}
+// NewStrategy creates and returns a strategy instance. ingestUsers are the users
+// allowed to modify the spec of an existing FalcoEvent, ie, the ingest path.
+func NewStrategy(typer runtime.ObjectTyper, ingestUsers ...string) strategy {
+	return strategy{typer, names.SimpleNameGenerator, sets.New[string](ingestUsers...)}
 }
 
 // strategy implements verification logic for FalcoEvents.
 type strategy struct {
 	runtime.ObjectTyper
 	names.NameGenerator
+
+	ingestUsers sets.Set[string]
+}
+
+// isIngestRequest returns true if the request was made by the falco event ingest path.
+func (s strategy) isIngestRequest(ctx context.Context) bool {
+	u, ok := genericapirequest.UserFrom(ctx)
+	return ok && s.ingestUsers.Has(u.GetName())
 }
 
 // DefaultGarbageCollectionPolicy returns OrphanDependents for v1 for backwards compatibility,
@@ -76,15 +88,22 @@ func (strategy) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
 		api.GroupName + "/v1alpha1": fieldpath.NewSet(
 			fieldpath.MakePathOrDie("status"),
 		),
+		api.GroupName + "/v1beta1": fieldpath.NewSet(
+			fieldpath.MakePathOrDie("status"),
+		),
 	}
 
 	return fields
 }
 
-// PrepareForCreate clears the status of a replication controller before creation.
+// PrepareForCreate clears the status of a FalcoEvent before creation. The status is
+// only written through the status subresource.
 func (strategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
 	controller := obj.(*api.FalcoEvent)
-	// controller.Status = api.FalcoEventStatus{}
+	controller.Status = api.FalcoEventStatus{}
+
+	// the structured sections always reflect the output fields, malformed fields are reported by Validate
+	_ = controller.Spec.SetSectionsFromOutputFields()
 
 	controller.Generation = 1
 }
@@ -93,8 +112,10 @@ func (strategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
 func (strategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
 	newController := obj.(*api.FalcoEvent)
 	oldController := old.(*api.FalcoEvent)
-	//// update is not allowed to set status
-	//newController.Status = oldController.Status
+	// update is not allowed to set status
+	newController.Status = oldController.Status
+
+	_ = newController.Spec.SetSectionsFromOutputFields()
 
 	// Any changes to the spec increment the generation number, any changes to the
 	// status should reflect the generation number of the corresponding object. We push
@@ -109,13 +130,15 @@ func (strategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
 	}
 }
 
-// Validate validates a new replication controller.
+// Validate validates a new FalcoEvent.
 func (strategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+	return ValidateFalcoEvent(obj.(*api.FalcoEvent))
 }
 
 // WarningsOnCreate returns warnings for the creation of the given object.
-func (strategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string { return nil }
+func (strategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	return WarningsForFalcoEvent(obj.(*api.FalcoEvent))
+}
 
 // Canonicalize normalizes the object after validation.
 func (strategy) Canonicalize(obj runtime.Object) {
@@ -128,8 +151,8 @@ func (strategy) AllowCreateOnUpdate() bool {
 }
 
 // ValidateUpdate is the default update validation for an end user.
-func (strategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+func (s strategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	return ValidateFalcoEventUpdate(obj.(*api.FalcoEvent), old.(*api.FalcoEvent), s.isIngestRequest(ctx))
 }
 
 // WarningsOnUpdate returns warnings for the given update.
@@ -143,7 +166,13 @@ func (strategy) AllowUnconditionalUpdate() bool {
 
 // ControllerToSelectableFields returns a field set that represents the object.
 func ControllerToSelectableFields(controller *api.FalcoEvent) fields.Set {
-	return generic.ObjectMetaFieldsSet(&controller.ObjectMeta, true)
+	objectMetaFieldsSet := generic.ObjectMetaFieldsSet(&controller.ObjectMeta, true)
+	specificFieldsSet := fields.Set{
+		"spec.rule":     controller.Spec.Rule,
+		"spec.priority": string(controller.Spec.Priority),
+		"spec.source":   controller.Spec.Source,
+	}
+	return generic.MergeFieldsSets(objectMetaFieldsSet, specificFieldsSet)
 }
 
 // GetAttrs returns labels and fields of a given object for filtering purposes.
@@ -155,6 +184,14 @@ func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
 	return labels.Set(rc.Labels), ControllerToSelectableFields(rc), nil
 }
 
+// GetEventTime returns the time Falco observed the event. It is used to partition FalcoEvents by time.
+func GetEventTime(obj runtime.Object) time.Time {
+	if fe, ok := obj.(*api.FalcoEvent); ok {
+		return fe.Spec.Time.Time
+	}
+	return time.Time{}
+}
+
 // MatchController is the filter used by the generic etcd backend to route
 // watch events from etcd to clients of the apiserver only interested in specific
 // labels/fields.
@@ -177,6 +214,9 @@ func (statusStrategy) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
 		api.GroupName + "/v1alpha1": fieldpath.NewSet(
 			fieldpath.MakePathOrDie("spec"),
 		),
+		api.GroupName + "/v1beta1": fieldpath.NewSet(
+			fieldpath.MakePathOrDie("spec"),
+		),
 	}
 }
 
@@ -188,7 +228,9 @@ func (statusStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Obj
 }
 
 func (statusStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
-	return field.ErrorList{}
+	newRc := obj.(*api.FalcoEvent)
+	oldRc := old.(*api.FalcoEvent)
+	return apimachineryvalidation.ValidateObjectMetaUpdate(&newRc.ObjectMeta, &oldRc.ObjectMeta, field.NewPath("metadata"))
 }
 
 // WarningsOnUpdate returns warnings for the given update.
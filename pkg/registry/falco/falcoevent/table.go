@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	api "kubeops.dev/falco-ui-server/apis/falco"
@@ -39,14 +40,39 @@ Adapted from:
   - https://github.com/kubernetes/kubernetes/blob/v1.25.0/pkg/printers/internalversion/printers.go#L190-L198
 */
 
+// OutputFieldColumn is an additional table column showing the value of a Falco output field.
+type OutputFieldColumn struct {
+	Name  string
+	Field string
+	// Wide columns are only shown with -o wide
+	Wide bool
+}
+
+// ParseOutputFieldColumn parses a column given as [name=]field, eg, File=fd.name.
+// If name is omitted, the field is used as the column name.
+func ParseOutputFieldColumn(s string, wide bool) (OutputFieldColumn, error) {
+	name, field, found := strings.Cut(s, "=")
+	if !found {
+		field = name
+	}
+	name = strings.TrimSpace(name)
+	field = strings.TrimSpace(field)
+	if name == "" || field == "" {
+		return OutputFieldColumn{}, fmt.Errorf("invalid column %q, expected [name=]field", s)
+	}
+	return OutputFieldColumn{Name: name, Field: field, Wide: wide}, nil
+}
+
 type defaultTableConvertor struct {
 	defaultQualifiedResource schema.GroupResource
+	columns                  []OutputFieldColumn
 }
 
 // NewTableConvertor creates a default convertor; the provided resource is used for error messages
 // if no resource info can be determined from the context passed to ConvertToTable.
-func NewTableConvertor(defaultQualifiedResource schema.GroupResource) rest.TableConvertor {
-	return defaultTableConvertor{defaultQualifiedResource: defaultQualifiedResource}
+// The given output field columns are appended to the built-in ones.
+func NewTableConvertor(defaultQualifiedResource schema.GroupResource, columns ...OutputFieldColumn) rest.TableConvertor {
+	return defaultTableConvertor{defaultQualifiedResource: defaultQualifiedResource, columns: columns}
 }
 
 func (c defaultTableConvertor) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
@@ -68,15 +94,43 @@ func (c defaultTableConvertor) ConvertToTable(ctx context.Context, object runtim
 			pod = podNS + "/" + podName
 		}
 
+		var image, process, user string
+		if o.Spec.Container != nil {
+			image = o.Spec.Container.ImageRepository
+			if o.Spec.Container.ImageTag != "" {
+				image += ":" + o.Spec.Container.ImageTag
+			}
+		}
+		if o.Spec.Process != nil {
+			process = o.Spec.Process.Name
+			user = o.Spec.Process.User
+		}
+
+		cells := []any{
+			ConvertToHumanReadableDateType(metav1.NewTime(o.Spec.Time.Time)),
+			o.Spec.Source,
+			string(o.Spec.Priority),
+			o.Spec.Nodename,
+			pod,
+			o.Spec.Rule,
+			image,
+			process,
+			user,
+			o.Spec.UUID,
+			strings.Join(o.Spec.Tags, ","),
+			o.Status.Count,
+			string(o.Status.TriageState),
+		}
+		if len(c.columns) > 0 {
+			// malformed output fields are rejected by validation, missing values are shown as empty cells
+			fields, _ := api.DecodeOutputFields(o.Spec.OutputFields.Raw)
+			for _, col := range c.columns {
+				cells = append(cells, outputFieldValue(fields[col.Field]))
+			}
+		}
+
 		table.Rows = append(table.Rows, metav1.TableRow{
-			Cells: []any{
-				ConvertToHumanReadableDateType(o.Spec.Time),
-				o.Spec.Source,
-				o.Spec.Priority,
-				o.Spec.Nodename,
-				pod,
-				o.Spec.Rule,
-			},
+			Cells:  cells,
 			Object: runtime.RawExtension{Object: obj},
 		})
 		return nil
@@ -108,11 +162,41 @@ func (c defaultTableConvertor) ConvertToTable(ctx context.Context, object runtim
 			{Name: "Node", Type: "string", Description: ""},
 			{Name: "Pod", Type: "string", Description: ""},
 			{Name: "Rule", Type: "string", Description: ""},
+			{Name: "Image", Type: "string", Description: "Container image", Priority: 1},
+			{Name: "Process", Type: "string", Description: "Process name", Priority: 1},
+			{Name: "User", Type: "string", Description: "User name", Priority: 1},
+			{Name: "UUID", Type: "string", Description: "UUID of the latest occurrence", Priority: 1},
+			{Name: "Tags", Type: "string", Description: "Tags of the rule", Priority: 1},
+			{Name: "Count", Type: "integer", Description: "Number of occurrences", Priority: 1},
+			{Name: "Triage", Type: "string", Description: "Triage state", Priority: 1},
+		}
+		for _, col := range c.columns {
+			var priority int32
+			if col.Wide {
+				priority = 1
+			}
+			table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{
+				Name:        col.Name,
+				Type:        "string",
+				Description: "Output field " + col.Field,
+				Priority:    priority,
+			})
 		}
 	}
 	return &table, nil
 }
 
+func outputFieldValue(v any) string {
+	switch u := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return u
+	default:
+		return fmt.Sprintf("%v", u)
+	}
+}
+
 // errNotAcceptable indicates the resource doesn't support Table conversion
 type errNotAcceptable struct {
 	resource schema.GroupResource
@@ -0,0 +1,95 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"context"
+	"testing"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestParseOutputFieldColumn(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    OutputFieldColumn
+		wantErr bool
+	}{{
+		in:   "File=fd.name",
+		want: OutputFieldColumn{Name: "File", Field: "fd.name"},
+	}, {
+		in:   "fd.name",
+		want: OutputFieldColumn{Name: "fd.name", Field: "fd.name"},
+	}, {
+		in:      "File=",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseOutputFieldColumn(tt.in, false)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseOutputFieldColumn() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseOutputFieldColumn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConvertToTable(t *testing.T) {
+	fe := newFalcoEvent()
+	fe.Spec.OutputFields = apiextensionsv1.JSON{Raw: []byte(`{"fd.name":"/etc/shadow","proc.name":"cat","user.name":"root","container.image.repository":"nginx","container.image.tag":"1.25"}`)}
+	if err := fe.Spec.SetSectionsFromOutputFields(); err != nil {
+		t.Fatal(err)
+	}
+	fe.Status.Count = 3
+	fe.Status.TriageState = api.TriageStateOpen
+
+	c := NewTableConvertor(api.Resource("falcoevents"), OutputFieldColumn{Name: "File", Field: "fd.name", Wide: true})
+	table, err := c.ConvertToTable(context.TODO(), fe, &metav1.TableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(table.Rows) != 1 || len(table.Rows[0].Cells) != len(table.ColumnDefinitions) {
+		t.Fatalf("got %d rows with %d cells for %d columns", len(table.Rows), len(table.Rows[0].Cells), len(table.ColumnDefinitions))
+	}
+	// the cells must be JSON values, the table is deep copied before it is served
+	table = table.DeepCopy()
+
+	want := map[string]any{
+		"Image":   "nginx:1.25",
+		"Process": "cat",
+		"User":    "root",
+		"Count":   int64(3),
+		"Triage":  "Open",
+		"File":    "/etc/shadow",
+	}
+	for i, col := range table.ColumnDefinitions {
+		if v, ok := want[col.Name]; ok {
+			if col.Priority != 1 {
+				t.Errorf("column %s has priority %d, want 1", col.Name, col.Priority)
+			}
+			if got := table.Rows[0].Cells[i]; got != v {
+				t.Errorf("column %s = %v, want %v", col.Name, got, v)
+			}
+		}
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+const (
+	// MaxOutputLength is the maximum size of the output of a FalcoEvent in bytes.
+	MaxOutputLength = 16 * 1024
+	// MaxOutputFieldsLength is the maximum size of the JSON encoded output fields of a FalcoEvent in bytes.
+	MaxOutputFieldsLength = 256 * 1024
+)
+
+const (
+	maxRuleLength   = 1024
+	maxSourceLength = 253
+	maxTags         = 64
+	maxTagLength    = 253
+
+	// clock skew tolerated before an event time is reported as being in the future
+	maxFutureSkew = 5 * time.Minute
+)
+
+var supportedPriorities = []string{
+	types.PriorityType(types.Emergency).String(),
+	types.PriorityType(types.Alert).String(),
+	types.PriorityType(types.Critical).String(),
+	types.PriorityType(types.Error).String(),
+	types.PriorityType(types.Warning).String(),
+	types.PriorityType(types.Notice).String(),
+	types.PriorityType(types.Informational).String(),
+	types.PriorityType(types.Debug).String(),
+}
+
+// knownSources lists the event sources shipped with Falco and its official plugins.
+var knownSources = map[string]bool{
+	"syscall":        true,
+	"syscalls":       true,
+	"k8s_audit":      true,
+	"aws_cloudtrail": true,
+	"okta":           true,
+	"github":         true,
+	"gcp_auditlog":   true,
+	"k8saudit":       true,
+	"k8saudit-eks":   true,
+	"k8saudit-gke":   true,
+	"k8saudit-aks":   true,
+}
+
+// ValidateFalcoEvent validates a FalcoEvent on creation.
+func ValidateFalcoEvent(fe *api.FalcoEvent) field.ErrorList {
+	allErrs := apimachineryvalidation.ValidateObjectMeta(&fe.ObjectMeta, false, apimachineryvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateWorkloadLabels(fe.Labels, field.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, ValidateFalcoEventSpec(&fe.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateFalcoEventUpdate validates an update of a FalcoEvent. The spec of an event is a record
+// of what Falco observed, so it may only be changed by the ingest path.
+func ValidateFalcoEventUpdate(newFe, oldFe *api.FalcoEvent, allowSpecUpdate bool) field.ErrorList {
+	allErrs := apimachineryvalidation.ValidateObjectMetaUpdate(&newFe.ObjectMeta, &oldFe.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateWorkloadLabels(newFe.Labels, field.NewPath("metadata", "labels"))...)
+	allErrs = append(allErrs, ValidateFalcoEventSpec(&newFe.Spec, field.NewPath("spec"))...)
+	if !allowSpecUpdate && !apiequality.Semantic.DeepEqual(newFe.Spec, oldFe.Spec) {
+		allErrs = append(allErrs, field.Forbidden(field.NewPath("spec"), "spec is immutable once the event is created"))
+	}
+	return allErrs
+}
+
+// ValidateFalcoEventSpec validates the attributes reported by Falco.
+func ValidateFalcoEventSpec(spec *api.FalcoEventSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if spec.Priority == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("priority"), ""))
+	} else if p := types.Priority(string(spec.Priority)); p == types.Default || p.String() != string(spec.Priority) {
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("priority"), string(spec.Priority), supportedPriorities))
+	}
+
+	if spec.Rule == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("rule"), ""))
+	} else if len(spec.Rule) > maxRuleLength {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("rule"), "", maxRuleLength))
+	}
+
+	if spec.Time.IsZero() {
+		allErrs = append(allErrs, field.Required(fldPath.Child("time"), ""))
+	}
+
+	if spec.Source == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("source"), ""))
+	} else if len(spec.Source) > maxSourceLength {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("source"), "", maxSourceLength))
+	}
+
+	if len(spec.Output) > MaxOutputLength {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("output"), "", MaxOutputLength))
+	}
+
+	if n := len(spec.OutputFields.Raw); n > MaxOutputFieldsLength {
+		allErrs = append(allErrs, field.TooLong(fldPath.Child("outputFields"), "", MaxOutputFieldsLength))
+	} else if n > 0 {
+		var fields map[string]any
+		if err := json.Unmarshal(spec.OutputFields.Raw, &fields); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("outputFields"), "", fmt.Sprintf("must be a JSON object: %v", err)))
+		}
+	}
+
+	if len(spec.Tags) > maxTags {
+		allErrs = append(allErrs, field.TooMany(fldPath.Child("tags"), len(spec.Tags), maxTags))
+	}
+	for i, tag := range spec.Tags {
+		if tag == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("tags").Index(i), ""))
+		} else if len(tag) > maxTagLength {
+			allErrs = append(allErrs, field.TooLong(fldPath.Child("tags").Index(i), "", maxTagLength))
+		}
+	}
+
+	if spec.Nodename != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.Nodename) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("nodename"), spec.Nodename, msg))
+		}
+	}
+	return allErrs
+}
+
+// validateWorkloadLabels checks that the labels copied from Falco output fields
+// hold names that could identify a real namespace, pod or node.
+func validateWorkloadLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if ns, ok := labels[api.LabelNamespaceName]; ok {
+		for _, msg := range validation.IsDNS1123Label(ns) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(api.LabelNamespaceName), ns, msg))
+		}
+	}
+	if pod, ok := labels[api.LabelPodName]; ok {
+		for _, msg := range validation.IsDNS1123Subdomain(pod) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(api.LabelPodName), pod, msg))
+		}
+	}
+	if node, ok := labels[api.LabelNodeName]; ok {
+		for _, msg := range validation.IsDNS1123Subdomain(node) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(api.LabelNodeName), node, msg))
+		}
+	}
+	if _, ok := labels[api.LabelPodName]; ok {
+		if _, ok := labels[api.LabelNamespaceName]; !ok {
+			allErrs = append(allErrs, field.Required(fldPath.Key(api.LabelNamespaceName), "must be set along with "+api.LabelPodName))
+		}
+	}
+	return allErrs
+}
+
+// WarningsForFalcoEvent returns warnings for input that is accepted but
+// probably not what Falco would have sent.
+func WarningsForFalcoEvent(fe *api.FalcoEvent) []string {
+	var warnings []string
+	if fe.Spec.Time.After(time.Now().Add(maxFutureSkew)) {
+		warnings = append(warnings, fmt.Sprintf("spec.time %s is in the future", fe.Spec.Time.UTC().Format(time.RFC3339)))
+	}
+	if fe.Spec.Output == "" {
+		warnings = append(warnings, "spec.output is empty")
+	}
+	if len(fe.Spec.OutputFields.Raw) == 0 {
+		warnings = append(warnings, "spec.outputFields is empty")
+	}
+	if fe.Spec.Source != "" && !knownSources[fe.Spec.Source] {
+		warnings = append(warnings, fmt.Sprintf("spec.source %q is not a known Falco event source", fe.Spec.Source))
+	}
+	if node, ok := fe.Labels[api.LabelNodeName]; ok && fe.Spec.Nodename != "" && node != fe.Spec.Nodename {
+		warnings = append(warnings, fmt.Sprintf("label %s=%s does not match spec.nodename %s", api.LabelNodeName, node, fe.Spec.Nodename))
+	}
+	return warnings
+}
@@ -0,0 +1,151 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package request
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apiserver/pkg/authentication/user"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+)
+
+func newFalcoEvent() *api.FalcoEvent {
+	return &api.FalcoEvent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "fe-42",
+			ResourceVersion: "1",
+			Labels: map[string]string{
+				api.LabelNamespaceName: "default",
+				api.LabelPodName:       "nginx-7d8b49557c-x2v4n",
+			},
+		},
+		Spec: api.FalcoEventSpec{
+			Output:       "A shell was spawned in a container",
+			Priority:     "Notice",
+			Rule:         "Terminal shell in container",
+			Time:         metav1.NowMicro(),
+			OutputFields: apiextensionsv1.JSON{Raw: []byte(`{"proc.name":"bash"}`)},
+			Source:       "syscalls",
+		},
+	}
+}
+
+func TestValidateFalcoEvent(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(fe *api.FalcoEvent)
+		wantErr bool
+	}{{
+		name:   "valid",
+		mutate: func(fe *api.FalcoEvent) {},
+	}, {
+		name:    "unknown priority",
+		mutate:  func(fe *api.FalcoEvent) { fe.Spec.Priority = "Urgent" },
+		wantErr: true,
+	}, {
+		name:    "non canonical priority",
+		mutate:  func(fe *api.FalcoEvent) { fe.Spec.Priority = "info" },
+		wantErr: true,
+	}, {
+		name:    "missing rule",
+		mutate:  func(fe *api.FalcoEvent) { fe.Spec.Rule = "" },
+		wantErr: true,
+	}, {
+		name:    "missing source",
+		mutate:  func(fe *api.FalcoEvent) { fe.Spec.Source = "" },
+		wantErr: true,
+	}, {
+		name:    "zero time",
+		mutate:  func(fe *api.FalcoEvent) { fe.Spec.Time = metav1.MicroTime{} },
+		wantErr: true,
+	}, {
+		name:    "malformed output fields",
+		mutate:  func(fe *api.FalcoEvent) { fe.Spec.OutputFields.Raw = []byte(`["proc.name"]`) },
+		wantErr: true,
+	}, {
+		name: "oversized output fields",
+		mutate: func(fe *api.FalcoEvent) {
+			fe.Spec.OutputFields.Raw = make([]byte, MaxOutputFieldsLength+1)
+		},
+		wantErr: true,
+	}, {
+		name:    "invalid namespace label",
+		mutate:  func(fe *api.FalcoEvent) { fe.Labels[api.LabelNamespaceName] = "Not_A_Namespace" },
+		wantErr: true,
+	}, {
+		name:    "pod without namespace",
+		mutate:  func(fe *api.FalcoEvent) { delete(fe.Labels, api.LabelNamespaceName) },
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fe := newFalcoEvent()
+			tt.mutate(fe)
+			if errs := ValidateFalcoEvent(fe); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("ValidateFalcoEvent() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidateUpdate(t *testing.T) {
+	s := NewStrategy(nil, "system:serviceaccount:falco:falco-ui-server")
+
+	tests := []struct {
+		name    string
+		user    string
+		wantErr bool
+	}{{
+		name: "ingest user",
+		user: "system:serviceaccount:falco:falco-ui-server",
+	}, {
+		name:    "other user",
+		user:    "jane",
+		wantErr: true,
+	}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			old := newFalcoEvent()
+			fe := newFalcoEvent()
+			fe.Spec.Time = metav1.NewMicroTime(old.Spec.Time.Add(time.Minute))
+
+			ctx := genericapirequest.WithUser(context.TODO(), &user.DefaultInfo{Name: tt.user})
+			if errs := s.ValidateUpdate(ctx, fe, old); (len(errs) > 0) != tt.wantErr {
+				t.Errorf("ValidateUpdate() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWarningsForFalcoEvent(t *testing.T) {
+	fe := newFalcoEvent()
+	if w := WarningsForFalcoEvent(fe); len(w) != 0 {
+		t.Errorf("WarningsForFalcoEvent() = %v, want none", w)
+	}
+
+	fe.Spec.Time = metav1.NewMicroTime(time.Now().Add(time.Hour))
+	fe.Spec.Source = "carrier_pigeon"
+	if w := WarningsForFalcoEvent(fe); len(w) != 2 {
+		t.Errorf("WarningsForFalcoEvent() = %v, want 2 warnings", w)
+	}
+}
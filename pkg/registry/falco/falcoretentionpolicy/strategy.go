@@ -0,0 +1,166 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoretentionpolicy
+
+import (
+	"context"
+	"fmt"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/apiserver/pkg/registry/generic"
+	apistorage "k8s.io/apiserver/pkg/storage"
+	"k8s.io/apiserver/pkg/storage/names"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// NewStrategy creates and returns a strategy instance.
+func NewStrategy(typer runtime.ObjectTyper) strategy {
+	return strategy{typer, names.SimpleNameGenerator}
+}
+
+// strategy implements verification logic for FalcoRetentionPolicies.
+type strategy struct {
+	runtime.ObjectTyper
+	names.NameGenerator
+}
+
+// NamespaceScoped returns false because FalcoRetentionPolicies are cluster scoped.
+func (strategy) NamespaceScoped() bool {
+	return false
+}
+
+// GetResetFields returns the set of fields that get reset by the strategy
+// and should not be modified by the user.
+func (strategy) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
+	return map[fieldpath.APIVersion]*fieldpath.Set{
+		api.GroupName + "/v1beta1": fieldpath.NewSet(
+			fieldpath.MakePathOrDie("status"),
+		),
+	}
+}
+
+// PrepareForCreate clears the status of a FalcoRetentionPolicy before creation.
+func (strategy) PrepareForCreate(ctx context.Context, obj runtime.Object) {
+	policy := obj.(*api.FalcoRetentionPolicy)
+	policy.Status = api.FalcoRetentionPolicyStatus{}
+	policy.Generation = 1
+}
+
+// PrepareForUpdate clears fields that are not allowed to be set by end users on update.
+func (strategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newPolicy := obj.(*api.FalcoRetentionPolicy)
+	oldPolicy := old.(*api.FalcoRetentionPolicy)
+	// update is not allowed to set status
+	newPolicy.Status = oldPolicy.Status
+
+	if !apiequality.Semantic.DeepEqual(oldPolicy.Spec, newPolicy.Spec) {
+		newPolicy.Generation = oldPolicy.Generation + 1
+	}
+}
+
+// Validate validates a new FalcoRetentionPolicy.
+func (strategy) Validate(ctx context.Context, obj runtime.Object) field.ErrorList {
+	return ValidateFalcoRetentionPolicy(obj.(*api.FalcoRetentionPolicy))
+}
+
+// WarningsOnCreate returns warnings for the creation of the given object.
+func (strategy) WarningsOnCreate(ctx context.Context, obj runtime.Object) []string {
+	return nil
+}
+
+// Canonicalize normalizes the object after validation.
+func (strategy) Canonicalize(obj runtime.Object) {
+}
+
+// AllowCreateOnUpdate is false for FalcoRetentionPolicies; this means a POST is
+// needed to create one.
+func (strategy) AllowCreateOnUpdate() bool {
+	return false
+}
+
+// ValidateUpdate is the default update validation for an end user.
+func (strategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	return ValidateFalcoRetentionPolicyUpdate(obj.(*api.FalcoRetentionPolicy), old.(*api.FalcoRetentionPolicy))
+}
+
+// WarningsOnUpdate returns warnings for the given update.
+func (strategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
+	return nil
+}
+
+func (strategy) AllowUnconditionalUpdate() bool {
+	return true
+}
+
+// GetAttrs returns labels and fields of a given object for filtering purposes.
+func GetAttrs(obj runtime.Object) (labels.Set, fields.Set, error) {
+	policy, ok := obj.(*api.FalcoRetentionPolicy)
+	if !ok {
+		return nil, nil, fmt.Errorf("given object is not a FalcoRetentionPolicy")
+	}
+	return labels.Set(policy.Labels), generic.ObjectMetaFieldsSet(&policy.ObjectMeta, false), nil
+}
+
+// MatchPolicy is the filter used by the generic etcd backend to route
+// watch events from etcd to clients of the apiserver only interested in specific
+// labels/fields.
+func MatchPolicy(label labels.Selector, field fields.Selector) apistorage.SelectionPredicate {
+	return apistorage.SelectionPredicate{
+		Label:    label,
+		Field:    field,
+		GetAttrs: GetAttrs,
+	}
+}
+
+type statusStrategy struct {
+	strategy
+}
+
+// GetResetFields returns the set of fields that get reset by the strategy
+// and should not be modified by the user.
+func (statusStrategy) GetResetFields() map[fieldpath.APIVersion]*fieldpath.Set {
+	return map[fieldpath.APIVersion]*fieldpath.Set{
+		api.GroupName + "/v1beta1": fieldpath.NewSet(
+			fieldpath.MakePathOrDie("spec"),
+		),
+	}
+}
+
+func (statusStrategy) PrepareForUpdate(ctx context.Context, obj, old runtime.Object) {
+	newPolicy := obj.(*api.FalcoRetentionPolicy)
+	oldPolicy := old.(*api.FalcoRetentionPolicy)
+	// update is not allowed to set spec
+	newPolicy.Spec = oldPolicy.Spec
+}
+
+func (statusStrategy) ValidateUpdate(ctx context.Context, obj, old runtime.Object) field.ErrorList {
+	newPolicy := obj.(*api.FalcoRetentionPolicy)
+	oldPolicy := old.(*api.FalcoRetentionPolicy)
+	return apimachineryvalidation.ValidateObjectMetaUpdate(&newPolicy.ObjectMeta, &oldPolicy.ObjectMeta, field.NewPath("metadata"))
+}
+
+// WarningsOnUpdate returns warnings for the given update.
+func (statusStrategy) WarningsOnUpdate(ctx context.Context, obj, old runtime.Object) []string {
+	return nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoretentionpolicy
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+	festorage "kubeops.dev/falco-ui-server/pkg/registry/falco/falcoevent"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	genericapirequest "k8s.io/apiserver/pkg/endpoints/request"
+	"k8s.io/apiserver/pkg/registry/rest"
+)
+
+type defaultTableConvertor struct {
+	defaultQualifiedResource schema.GroupResource
+}
+
+// NewTableConvertor creates a default convertor; the provided resource is used for error messages
+// if no resource info can be determined from the context passed to ConvertToTable.
+func NewTableConvertor(defaultQualifiedResource schema.GroupResource) rest.TableConvertor {
+	return defaultTableConvertor{defaultQualifiedResource: defaultQualifiedResource}
+}
+
+func (c defaultTableConvertor) ConvertToTable(ctx context.Context, object runtime.Object, tableOptions runtime.Object) (*metav1.Table, error) {
+	var table metav1.Table
+	fn := func(obj runtime.Object) error {
+		o, ok := obj.(*api.FalcoRetentionPolicy)
+		if !ok {
+			resource := c.defaultQualifiedResource
+			if info, ok := genericapirequest.RequestInfoFrom(ctx); ok {
+				resource = schema.GroupResource{Group: info.APIGroup, Resource: info.Resource}
+			}
+			return errNotAcceptable{resource: resource}
+		}
+
+		var ttl, maxCount string
+		if o.Spec.TTL != nil {
+			ttl = o.Spec.TTL.Duration.String()
+		}
+		if o.Spec.MaxCount != nil {
+			maxCount = fmt.Sprintf("%d", *o.Spec.MaxCount)
+		}
+		priorities := make([]string, 0, len(o.Spec.Selector.Priorities))
+		for _, p := range o.Spec.Selector.Priorities {
+			priorities = append(priorities, string(p))
+		}
+
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells: []any{
+				o.Name,
+				int64(o.Spec.Precedence),
+				ttl,
+				maxCount,
+				o.Spec.Archive,
+				o.Status.MatchedEvents,
+				o.Status.DeletedEvents,
+				festorage.ConvertToHumanReadableDateType(o.CreationTimestamp),
+				strings.Join(priorities, ","),
+				strings.Join(o.Spec.Selector.Rules, ","),
+			},
+			Object: runtime.RawExtension{Object: obj},
+		})
+		return nil
+	}
+	switch {
+	case meta.IsListType(object):
+		if err := meta.EachListItem(object, fn); err != nil {
+			return nil, err
+		}
+	default:
+		if err := fn(object); err != nil {
+			return nil, err
+		}
+	}
+	if m, err := meta.ListAccessor(object); err == nil {
+		table.ResourceVersion = m.GetResourceVersion()
+		table.Continue = m.GetContinue()
+		table.RemainingItemCount = m.GetRemainingItemCount()
+	} else {
+		if m, err := meta.CommonAccessor(object); err == nil {
+			table.ResourceVersion = m.GetResourceVersion()
+		}
+	}
+	if opt, ok := tableOptions.(*metav1.TableOptions); !ok || !opt.NoHeaders {
+		table.ColumnDefinitions = []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name", Description: ""},
+			{Name: "Precedence", Type: "integer", Description: "Lower values are evaluated first"},
+			{Name: "TTL", Type: "string", Description: "Time to live of the matching events"},
+			{Name: "Max Count", Type: "string", Description: "Maximum number of matching events kept"},
+			{Name: "Archive", Type: "boolean", Description: "Archive matching events before deletion"},
+			{Name: "Matched", Type: "integer", Description: "Events matched in the last evaluation"},
+			{Name: "Deleted", Type: "integer", Description: "Events deleted by this policy"},
+			{Name: "Age", Type: "string", Description: ""},
+			{Name: "Priorities", Type: "string", Description: "Selected priorities", Priority: 1},
+			{Name: "Rules", Type: "string", Description: "Selected rules", Priority: 1},
+		}
+	}
+	return &table, nil
+}
+
+// errNotAcceptable indicates the resource doesn't support Table conversion
+type errNotAcceptable struct {
+	resource schema.GroupResource
+}
+
+func (e errNotAcceptable) Error() string {
+	return fmt.Sprintf("the resource %s does not support being converted to a Table", e.resource)
+}
+
+func (e errNotAcceptable) Status() metav1.Status {
+	return metav1.Status{
+		Status:  metav1.StatusFailure,
+		Code:    http.StatusNotAcceptable,
+		Reason:  metav1.StatusReason("NotAcceptable"),
+		Message: e.Error(),
+	}
+}
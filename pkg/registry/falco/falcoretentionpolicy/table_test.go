@@ -0,0 +1,42 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoretentionpolicy
+
+import (
+	"context"
+	"testing"
+
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConvertToTable(t *testing.T) {
+	p := &api.FalcoRetentionPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: "debug"},
+		Spec:       api.FalcoRetentionPolicySpec{Precedence: 10},
+	}
+	table, err := NewTableConvertor(api.Resource("falcoretentionpolicies")).ConvertToTable(context.TODO(), p, &metav1.TableOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// the cells must be JSON values, the table is deep copied before it is served
+	table = table.DeepCopy()
+	if got := table.Rows[0].Cells[1]; got != int64(10) {
+		t.Errorf("precedence cell = %v, want 10", got)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package falcoretentionpolicy
+
+import (
+	api "kubeops.dev/falco-ui-server/apis/falco"
+
+	apimachineryvalidation "k8s.io/apimachinery/pkg/api/validation"
+	metav1validation "k8s.io/apimachinery/pkg/apis/meta/v1/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+var supportedPriorities = []string{
+	string(api.PriorityEmergency),
+	string(api.PriorityAlert),
+	string(api.PriorityCritical),
+	string(api.PriorityError),
+	string(api.PriorityWarning),
+	string(api.PriorityNotice),
+	string(api.PriorityInformational),
+	string(api.PriorityDebug),
+}
+
+// ValidateFalcoRetentionPolicy validates a FalcoRetentionPolicy on creation.
+func ValidateFalcoRetentionPolicy(policy *api.FalcoRetentionPolicy) field.ErrorList {
+	allErrs := apimachineryvalidation.ValidateObjectMeta(&policy.ObjectMeta, false, apimachineryvalidation.NameIsDNSSubdomain, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateSpec(&policy.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+// ValidateFalcoRetentionPolicyUpdate validates an update of a FalcoRetentionPolicy.
+func ValidateFalcoRetentionPolicyUpdate(policy, old *api.FalcoRetentionPolicy) field.ErrorList {
+	allErrs := apimachineryvalidation.ValidateObjectMetaUpdate(&policy.ObjectMeta, &old.ObjectMeta, field.NewPath("metadata"))
+	allErrs = append(allErrs, validateSpec(&policy.Spec, field.NewPath("spec"))...)
+	return allErrs
+}
+
+func validateSpec(spec *api.FalcoRetentionPolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	if spec.TTL == nil && spec.MaxCount == nil {
+		allErrs = append(allErrs, field.Required(fldPath, "at least one of ttl or maxCount must be set"))
+	}
+	if spec.TTL != nil && spec.TTL.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("ttl"), spec.TTL.Duration.String(), "must be greater than zero"))
+	}
+	if spec.MaxCount != nil && *spec.MaxCount < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxCount"), *spec.MaxCount, "must not be negative"))
+	}
+
+	selPath := fldPath.Child("selector")
+	if spec.Selector.NamespaceSelector != nil {
+		allErrs = append(allErrs, metav1validation.ValidateLabelSelector(spec.Selector.NamespaceSelector, metav1validation.LabelSelectorValidationOptions{}, selPath.Child("namespaceSelector"))...)
+	}
+	for i, p := range spec.Selector.Priorities {
+		if !isSupportedPriority(string(p)) {
+			allErrs = append(allErrs, field.NotSupported(selPath.Child("priorities").Index(i), p, supportedPriorities))
+		}
+	}
+	for i, r := range spec.Selector.Rules {
+		if r == "" {
+			allErrs = append(allErrs, field.Required(selPath.Child("rules").Index(i), ""))
+		}
+	}
+	for i, s := range spec.Selector.Sources {
+		if s == "" {
+			allErrs = append(allErrs, field.Required(selPath.Child("sources").Index(i), ""))
+		}
+	}
+	return allErrs
+}
+
+func isSupportedPriority(p string) bool {
+	for _, s := range supportedPriorities {
+		if s == p {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,186 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco"
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+	"kubeops.dev/falco-ui-server/pkg/falcosidekick/types"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/yaml"
+)
+
+// Policy sets the time FalcoEvents are kept for. The TTL of an event is taken from the first
+// matching override, else from the TTL of its priority, else from the default.
+//
+//	default: 24h
+//	priorities:
+//	  Critical: 2160h
+//	  Notice: 24h
+//	overrides:
+//	- rules: ["Terminal shell in container"]
+//	  namespaceSelector:
+//	    matchLabels:
+//	      env: prod
+//	  ttl: 720h
+type Policy struct {
+	// Default is the TTL of events without a more specific TTL. It defaults to --event-ttl.
+	Default    metav1.Duration            `json:"default,omitempty"`
+	Priorities map[string]metav1.Duration `json:"priorities,omitempty"`
+	Overrides  []Override                 `json:"overrides,omitempty"`
+
+	priorities map[types.PriorityType]time.Duration
+}
+
+// Override sets the TTL of the events matching all of its non-empty criteria.
+type Override struct {
+	Rules      []string `json:"rules,omitempty"`
+	Priorities []string `json:"priorities,omitempty"`
+	Sources    []string `json:"sources,omitempty"`
+	// NamespaceSelector matches the labels of the namespace of an event. Host events,
+	// ie, events without a namespace, never match.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty"`
+	TTL               metav1.Duration       `json:"ttl"`
+
+	priorities []types.PriorityType
+	selector   labels.Selector
+}
+
+// NewPolicy returns a policy keeping all events for ttl.
+func NewPolicy(ttl time.Duration) *Policy {
+	return &Policy{Default: metav1.Duration{Duration: ttl}}
+}
+
+// Load reads a policy from a YAML or JSON file. ttl is used as default if the policy does not set one.
+func Load(filename string, ttl time.Duration) (*Policy, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	var p Policy
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		return nil, fmt.Errorf("invalid retention policy %s: %v", filename, err)
+	}
+	if p.Default.Duration == 0 {
+		p.Default.Duration = ttl
+	}
+	if err := p.compile(); err != nil {
+		return nil, fmt.Errorf("invalid retention policy %s: %v", filename, err)
+	}
+	return &p, nil
+}
+
+func parsePriority(s string) (types.PriorityType, error) {
+	p := types.Priority(s)
+	if p == types.Default {
+		return p, fmt.Errorf("unknown priority %q", s)
+	}
+	return p, nil
+}
+
+func (p *Policy) compile() error {
+	if p.Default.Duration <= 0 {
+		return fmt.Errorf("default ttl must be positive")
+	}
+	p.priorities = make(map[types.PriorityType]time.Duration, len(p.Priorities))
+	for name, ttl := range p.Priorities {
+		prio, err := parsePriority(name)
+		if err != nil {
+			return err
+		}
+		if ttl.Duration <= 0 {
+			return fmt.Errorf("ttl of priority %s must be positive", name)
+		}
+		p.priorities[prio] = ttl.Duration
+	}
+	for i := range p.Overrides {
+		o := &p.Overrides[i]
+		if o.TTL.Duration <= 0 {
+			return fmt.Errorf("ttl of override %d must be positive", i)
+		}
+		o.priorities = nil
+		for _, name := range o.Priorities {
+			prio, err := parsePriority(name)
+			if err != nil {
+				return fmt.Errorf("override %d: %v", i, err)
+			}
+			o.priorities = append(o.priorities, prio)
+		}
+		o.selector = nil
+		if o.NamespaceSelector != nil {
+			sel, err := metav1.LabelSelectorAsSelector(o.NamespaceSelector)
+			if err != nil {
+				return fmt.Errorf("override %d: invalid namespace selector: %v", i, err)
+			}
+			o.selector = sel
+		}
+	}
+	return nil
+}
+
+// NeedsNamespaceLabels returns true if any override selects namespaces by label.
+func (p *Policy) NeedsNamespaceLabels() bool {
+	for _, o := range p.Overrides {
+		if o.selector != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// TTL returns the time the event is kept for. nsLabels are the labels of the namespace of the
+// event, they are only used if NeedsNamespaceLabels returns true.
+func (p *Policy) TTL(fe *api.FalcoEvent, nsLabels labels.Set) time.Duration {
+	prio := types.Priority(string(fe.Spec.Priority))
+	for _, o := range p.Overrides {
+		if o.matches(fe, prio, nsLabels) {
+			return o.TTL.Duration
+		}
+	}
+	if ttl, ok := p.priorities[prio]; ok {
+		return ttl
+	}
+	return p.Default.Duration
+}
+
+func (o *Override) matches(fe *api.FalcoEvent, prio types.PriorityType, nsLabels labels.Set) bool {
+	if len(o.Rules) > 0 && !slices.Contains(o.Rules, fe.Spec.Rule) {
+		return false
+	}
+	if len(o.priorities) > 0 && !slices.Contains(o.priorities, prio) {
+		return false
+	}
+	if len(o.Sources) > 0 && !slices.ContainsFunc(o.Sources, func(s string) bool {
+		return strings.EqualFold(s, fe.Spec.Source)
+	}) {
+		return false
+	}
+	if o.selector != nil {
+		if fe.Labels[falco.LabelNamespaceName] == "" || nsLabels == nil || !o.selector.Matches(nsLabels) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+/*
+Copyright AppsCode Inc. and Contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package retention
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"kubeops.dev/falco-ui-server/apis/falco"
+	api "kubeops.dev/falco-ui-server/apis/falco/v1beta1"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const testPolicy = `
+priorities:
+  Critical: 2160h
+  notice: 24h
+  Debug: 1h
+overrides:
+- rules: ["Terminal shell in container"]
+  namespaceSelector:
+    matchLabels:
+      env: prod
+  ttl: 720h
+- sources: [k8s_audit]
+  priorities: [Debug]
+  ttl: 12h
+`
+
+func TestPolicy(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "policy.yaml")
+	if err := os.WriteFile(filename, []byte(testPolicy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p, err := Load(filename, 48*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.NeedsNamespaceLabels() {
+		t.Error("NeedsNamespaceLabels() = false, want true")
+	}
+
+	event := func(rule string, prio api.Priority, source, ns string) *api.FalcoEvent {
+		fe := &api.FalcoEvent{
+			ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+			Spec:       api.FalcoEventSpec{Rule: rule, Priority: prio, Source: source},
+		}
+		if ns != "" {
+			fe.Labels[falco.LabelNamespaceName] = ns
+		}
+		return fe
+	}
+	prod := labels.Set{"env": "prod"}
+	tests := []struct {
+		name     string
+		fe       *api.FalcoEvent
+		nsLabels labels.Set
+		want     time.Duration
+	}{
+		{"default", event("Write below etc", api.PriorityWarning, "syscall", "demo"), nil, 48 * time.Hour},
+		{"priority", event("Write below etc", api.PriorityCritical, "syscall", "demo"), nil, 2160 * time.Hour},
+		{"priority case insensitive", event("Write below etc", api.PriorityNotice, "syscall", "demo"), nil, 24 * time.Hour},
+		{"rule in prod", event("Terminal shell in container", api.PriorityNotice, "syscall", "shop"), prod, 720 * time.Hour},
+		{"rule outside prod", event("Terminal shell in container", api.PriorityNotice, "syscall", "dev"), labels.Set{"env": "dev"}, 24 * time.Hour},
+		{"host event", event("Terminal shell in container", api.PriorityNotice, "syscall", ""), prod, 24 * time.Hour},
+		{"source and priority", event("Exec into pod", api.PriorityDebug, "K8s_Audit", ""), nil, 12 * time.Hour},
+		{"source only", event("Exec into pod", api.PriorityWarning, "k8s_audit", ""), nil, 48 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := p.TTL(tt.fe, tt.nsLabels); got != tt.want {
+			t.Errorf("%s: TTL() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestLoadInvalid(t *testing.T) {
+	for name, policy := range map[string]string{
+		"unknown priority": "priorities:\n  Urgent: 1h\n",
+		"negative ttl":     "overrides:\n- rules: [x]\n  ttl: -1h\n",
+		"missing ttl":      "overrides:\n- rules: [x]\n",
+		"unknown field":    "priority:\n  Debug: 1h\n",
+		"invalid selector": "overrides:\n- namespaceSelector:\n    matchExpressions:\n    - {key: env, operator: Foo}\n  ttl: 1h\n",
+	} {
+		filename := filepath.Join(t.TempDir(), "policy.yaml")
+		if err := os.WriteFile(filename, []byte(policy), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(filename, time.Hour); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}